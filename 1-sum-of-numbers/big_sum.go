@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// BigSum returns the exact total of numbers. Nil elements are treated as zero.
+func BigSum(numbers []*big.Int) *big.Int {
+	sum := new(big.Int)
+	for _, n := range numbers {
+		if n != nil {
+			sum.Add(sum, n)
+		}
+	}
+	return sum
+}
+
+// BigRatSum returns the exact total of numbers. Nil elements are treated as zero.
+func BigRatSum(numbers []*big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	for _, n := range numbers {
+		if n != nil {
+			sum.Add(sum, n)
+		}
+	}
+	return sum
+}
+
+// maxDecimalExponent bounds the exponent BigDecimalSum accepts, so that a short
+// input such as "1e999999999" cannot force a huge exact expansion.
+const maxDecimalExponent = 1000
+
+// BigDecimalSum adds decimal strings such as "12.50", "-0.001" or "1e-3" exactly
+// and returns the total as a plain decimal string. The result keeps at least as
+// many fractional digits as the most precise input, so "1.50" + "2.50" is "4.00".
+// Only decimal syntax is accepted: an optional sign, digits with an optional
+// '.', and an optional e/E exponent of at most 1000 in magnitude.
+func BigDecimalSum(values []string) (string, error) {
+	sum := new(big.Rat)
+	scale := 0
+	for i, v := range values {
+		v = strings.TrimSpace(v)
+		if err := checkDecimal(v); err != nil {
+			return "", fmt.Errorf("sum: value %d: %q: %w", i, v, err)
+		}
+		r, ok := new(big.Rat).SetString(v)
+		if !ok {
+			return "", fmt.Errorf("sum: value %d: invalid decimal %q", i, v)
+		}
+		scale = max(scale, fractionDigits(v), decimalPlaces(r))
+		sum.Add(sum, r)
+	}
+	return sum.FloatString(scale), nil
+}
+
+// checkDecimal reports whether v is a plain decimal number with an exponent
+// no larger than maxDecimalExponent.
+func checkDecimal(v string) error {
+	mantissa, exponent, hasExponent := strings.Cut(strings.ToLower(v), "e")
+	mantissa = trimSign(mantissa)
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
+	if intPart+fracPart == "" || !allDigits(intPart) || !allDigits(fracPart) {
+		return errors.New("not a decimal number")
+	}
+	if !hasExponent {
+		return nil
+	}
+	if exponent = trimSign(exponent); exponent == "" || !allDigits(exponent) {
+		return errors.New("invalid exponent")
+	}
+	// Leading zeros aside, a digit count above 4 already exceeds the limit and
+	// could overflow Atoi.
+	exponent = strings.TrimLeft(exponent, "0")
+	if e, _ := strconv.Atoi(exponent); len(exponent) > 4 || e > maxDecimalExponent {
+		return fmt.Errorf("exponent exceeds %d", maxDecimalExponent)
+	}
+	return nil
+}
+
+func trimSign(s string) string {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		return s[1:]
+	}
+	return s
+}
+
+func allDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// fractionDigits counts the digits written after the decimal point in plain notation.
+func fractionDigits(v string) int {
+	if strings.ContainsAny(v, "eE") {
+		return 0
+	}
+	dot := strings.IndexByte(v, '.')
+	if dot < 0 {
+		return 0
+	}
+	return len(v) - dot - 1
+}
+
+// decimalPlaces returns the number of fractional digits needed to write r exactly.
+// Any parsed decimal has a denominator of the form 2^a * 5^b, needing max(a, b) digits.
+func decimalPlaces(r *big.Rat) int {
+	d := new(big.Int).Set(r.Denom())
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+
+	fives := 0
+	five := big.NewInt(5)
+	q, m := new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(d, five, m)
+		if m.Sign() != 0 {
+			break
+		}
+		d.Set(q)
+		fives++
+	}
+	return max(twos, fives)
+}
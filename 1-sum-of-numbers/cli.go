@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const usage = `Usage: sum <command> [flags] [numbers...]
+
+Commands:
+  sum         total of all values
+  mean        arithmetic mean
+  min         smallest value
+  max         largest value
+  count       number of values
+  stddev      standard deviation (sample unless -population)
+  percentile  p-th percentile, set with -p
+
+Values are taken from -file (repeatable), then positional arguments,
+and from stdin when neither is given. Use "-" as a file name for stdin.
+Flags must come before the numbers; a negative number such as -7 starts
+the numbers, as does "--".
+
+Flags:
+  -file path      read values from path
+  -column col     read CSV input and use column col (1-based index or header name)
+  -header         skip the first CSV row (implied when -column is a name)
+  -p n            percentile to compute, 0-100 (default 50)
+  -population     use the population standard deviation
+`
+
+// fileList collects repeated -file flags.
+type fileList []string
+
+func (f *fileList) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// run executes the CLI with args (excluding the program name) and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return 2
+		}
+		return 0
+	}
+
+	cmd := args[0]
+	switch cmd {
+	case "sum", "mean", "min", "max", "count", "stddev", "percentile":
+	default:
+		fmt.Fprintf(stderr, "sum: unknown command %q\n\n%s", cmd, usage)
+		return 2
+	}
+
+	var files fileList
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	fs.Var(&files, "file", "read values from `path`")
+	column := fs.String("column", "", "CSV column (1-based index or header name)")
+	header := fs.Bool("header", false, "skip the first CSV row")
+	p := fs.Float64("p", 50, "percentile to compute")
+	population := fs.Bool("population", false, "use population standard deviation")
+	if err := fs.Parse(operandsAfterNegative(fs, args[1:])); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	var stats Stats
+	var values []float64
+	add := func(x float64) {
+		stats.Add(x)
+		if cmd == "percentile" {
+			values = append(values, x)
+		}
+	}
+
+	src := source{column: *column, header: *header}
+	if err := src.read(files, fs.Args(), stdin, add); err != nil {
+		fmt.Fprintln(stderr, "sum:", err)
+		return 1
+	}
+
+	var result float64
+	var err error
+	switch cmd {
+	case "sum":
+		result = stats.Sum()
+	case "mean":
+		result, err = stats.Mean()
+	case "min":
+		result, err = stats.Min()
+	case "max":
+		result, err = stats.Max()
+	case "count":
+		fmt.Fprintln(stdout, stats.Count())
+		return 0
+	case "stddev":
+		result, err = stats.StdDev(*population)
+	case "percentile":
+		result, err = Percentile(values, *p)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "sum:", err)
+		return 1
+	}
+
+	fmt.Fprintln(stdout, strconv.FormatFloat(result, 'f', -1, 64))
+	return 0
+}
+
+// operandsAfterNegative inserts "--" before the first negative number among
+// args that is not a flag's value, so "min -7 3" reads -7 as a number
+// rather than as an unknown flag.
+func operandsAfterNegative(fs *flag.FlagSet, args []string) []string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			return args
+		}
+		if _, err := strconv.ParseFloat(arg, 64); err == nil {
+			return slices.Insert(slices.Clone(args), i, "--")
+		}
+		name := strings.TrimLeft(arg, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if f := fs.Lookup(name); f != nil {
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !b.IsBoolFlag() {
+				i++ // skip the flag's value
+			}
+		}
+	}
+	return args
+}
+
+// source describes how numbers are laid out in files and stdin.
+type source struct {
+	column string // CSV column; empty for whitespace-separated input
+	header bool   // skip the first CSV row
+}
+
+// read feeds every value from files, args or stdin to add.
+func (s source) read(files, args []string, stdin io.Reader, add func(float64)) error {
+	for _, name := range files {
+		if err := s.readFile(name, stdin, add); err != nil {
+			return err
+		}
+	}
+	for _, arg := range args {
+		x, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("argument %q: %w", arg, err)
+		}
+		add(x)
+	}
+	if len(files) == 0 && len(args) == 0 {
+		return s.readValues(stdin, add)
+	}
+	return nil
+}
+
+func (s source) readFile(name string, stdin io.Reader, add func(float64)) error {
+	if name == "-" {
+		return s.readValues(stdin, add)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := s.readValues(f, add); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// readValues streams values from r. Without a column, r holds
+// whitespace-separated numbers; with one, r is CSV and only that column is read.
+func (s source) readValues(r io.Reader, add func(float64)) error {
+	if s.column != "" {
+		return s.readCSVColumn(r, add)
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		x, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return err
+		}
+		add(x)
+	}
+	return scanner.Err()
+}
+
+func (s source) readCSVColumn(r io.Reader, add func(float64)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	index, err := strconv.Atoi(s.column)
+	byName := err != nil
+	skipHeader := s.header || byName
+	if !byName {
+		if index < 1 {
+			return fmt.Errorf("column index must be 1 or greater, got %d", index)
+		}
+		index--
+	}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if skipHeader {
+			if byName {
+				index = findColumn(record, s.column)
+				if index < 0 {
+					return fmt.Errorf("column %q not found in header", s.column)
+				}
+			}
+			skipHeader = false
+			continue
+		}
+
+		if index >= len(record) {
+			return fmt.Errorf("line %d: no column %d", line, index+1)
+		}
+		field := strings.TrimSpace(record[index])
+		if field == "" {
+			continue
+		}
+		x, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		add(x)
+	}
+}
+
+func findColumn(header []string, name string) int {
+	for i, h := range header {
+		if strings.TrimSpace(h) == name {
+			return i
+		}
+	}
+	return -1
+}
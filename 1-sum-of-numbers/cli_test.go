@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	numbers := filepath.Join(dir, "numbers.txt")
+	if err := os.WriteFile(numbers, []byte("1 2 3\n4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	table := filepath.Join(dir, "table.csv")
+	if err := os.WriteFile(table, []byte("name,amount\na,10\nb,\"20.5\"\nc,30\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+		code     int
+	}{
+		{"sum args", []string{"sum", "1", "2", "3", "4", "5"}, "", "15", 0},
+		{"sum stdin", []string{"sum"}, "1\n2\n3\n", "6", 0},
+		{"sum file and args", []string{"sum", "-file", numbers, "10"}, "", "20", 0},
+		{"mean", []string{"mean", "2", "4", "6"}, "", "4", 0},
+		{"min", []string{"min", "--", "3", "-7", "5"}, "", "-7", 0},
+		{"max", []string{"max", "3", "7", "5"}, "", "7", 0},
+		{"leading negative", []string{"min", "-7", "3"}, "", "-7", 0},
+		{"negative after flag", []string{"percentile", "-p", "100", "-2", "-1"}, "", "-1", 0},
+		{"negative flag value", []string{"percentile", "-population", "-p", "0", "-2", "-1"}, "", "-2", 0},
+		{"count", []string{"count"}, "1 2 3 4", "4", 0},
+		{"stddev sample", []string{"stddev", "2", "4", "4", "4", "5", "5", "7", "9"}, "", "2.138089935299395", 0},
+		{"stddev population", []string{"stddev", "-population", "2", "4", "4", "4", "5", "5", "7", "9"}, "", "2", 0},
+		{"median", []string{"percentile", "1", "3", "2", "4"}, "", "2.5", 0},
+		{"p90", []string{"percentile", "-p", "90", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"}, "", "10", 0},
+		{"csv column by index", []string{"sum", "--column", "2", "-header", "-file", table}, "", "60.5", 0},
+		{"csv column by name", []string{"max", "--column", "amount"}, "name,amount\nx,1\ny,9\n", "9", 0},
+		{"missing csv column", []string{"sum", "--column", "price", "-file", table}, "", "", 1},
+		{"mean of nothing", []string{"mean"}, "", "", 1},
+		{"bad number", []string{"sum", "1", "x"}, "", "", 1},
+		{"unknown command", []string{"median"}, "", "", 2},
+		{"no command", nil, "", "", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.code {
+				t.Fatalf("expected exit code %d, got %d (stderr: %s)", tt.code, code, stderr.String())
+			}
+			if got := strings.TrimSpace(stdout.String()); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
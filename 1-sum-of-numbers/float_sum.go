@@ -0,0 +1,110 @@
+package main
+
+import "golang.org/x/exp/constraints"
+
+// SumMode selects the algorithm FloatSum uses to accumulate values.
+type SumMode int
+
+const (
+	// Naive adds values left to right, exactly like Sum.
+	Naive SumMode = iota
+	// Kahan carries a running compensation term for lost low-order bits.
+	Kahan
+	// Neumaier is Kahan's algorithm amended to stay accurate when an
+	// addend is larger in magnitude than the running sum.
+	Neumaier
+	// Pairwise recursively splits the input and adds the halves, bounding
+	// the error growth to O(log n) instead of O(n).
+	Pairwise
+)
+
+// pairwiseBlock is the size below which Pairwise falls back to a plain loop.
+const pairwiseBlock = 128
+
+type floatSumConfig struct {
+	mode SumMode
+}
+
+// FloatSumOption configures FloatSum.
+type FloatSumOption func(*floatSumConfig)
+
+// WithMode selects the summation algorithm. The default is Neumaier.
+func WithMode(mode SumMode) FloatSumOption {
+	return func(c *floatSumConfig) {
+		c.mode = mode
+	}
+}
+
+// FloatSum returns the total of numbers using a numerically stable algorithm.
+// It is a drop-in replacement for Sum on float slices.
+func FloatSum[T constraints.Float](numbers []T, opts ...FloatSumOption) T {
+	cfg := floatSumConfig{mode: Neumaier}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	switch cfg.mode {
+	case Kahan:
+		return kahanSum(numbers)
+	case Neumaier:
+		return neumaierSum(numbers)
+	case Pairwise:
+		return pairwiseSum(numbers)
+	default:
+		return Sum(numbers)
+	}
+}
+
+func kahanSum[T constraints.Float](numbers []T) T {
+	var sum, c T
+	for _, n := range numbers {
+		y := n - c
+		t := sum + y
+		c = (t - sum) - y
+		sum = t
+	}
+	return sum
+}
+
+func neumaierSum[T constraints.Float](numbers []T) T {
+	var acc neumaier[T]
+	for _, n := range numbers {
+		acc.add(n)
+	}
+	return acc.total()
+}
+
+// neumaier is a running Neumaier-compensated sum. The zero value is ready to use.
+type neumaier[T constraints.Float] struct {
+	sum T
+	c   T // compensation for lost low-order bits
+}
+
+func (a *neumaier[T]) add(n T) {
+	t := a.sum + n
+	if abs(a.sum) >= abs(n) {
+		a.c += (a.sum - t) + n
+	} else {
+		a.c += (n - t) + a.sum
+	}
+	a.sum = t
+}
+
+func (a *neumaier[T]) total() T {
+	return a.sum + a.c
+}
+
+func pairwiseSum[T constraints.Float](numbers []T) T {
+	if len(numbers) <= pairwiseBlock {
+		return Sum(numbers)
+	}
+	mid := len(numbers) / 2
+	return pairwiseSum(numbers[:mid]) + pairwiseSum(numbers[mid:])
+}
+
+func abs[T constraints.Float](x T) T {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
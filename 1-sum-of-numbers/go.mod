@@ -1,3 +1,5 @@
 module sum
 
 go 1.24.4
+
+require golang.org/x/exp v0.0.0-20260112195511-716be5621a96
@@ -1,22 +1,9 @@
 package main
 
 import (
-	"fmt"
+	"os"
 )
 
-func Sum(numbers []int) int {
-	if len(numbers) == 0{
-		return 0
-	}
-	sum:=0
-
-	for _,n := range numbers{
-		sum+=n
-	}
-	return sum
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
 }
-
-func main(){
-	fmt.Println(Sum([]int{1,2,3,4,5}))
-	fmt.Println(Sum([]int{}))
-}
\ No newline at end of file
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+)
+
+// DefaultParallelThreshold is the slice length below which ParallelSum
+// simply calls Sum. See BenchmarkParallelSum for how it was chosen.
+const DefaultParallelThreshold = 1 << 16
+
+type parallelConfig struct {
+	threshold int
+	workers   int
+}
+
+// ParallelOption configures ParallelSum.
+type ParallelOption func(*parallelConfig)
+
+// WithThreshold sets the minimum slice length for which ParallelSum spawns goroutines.
+func WithThreshold(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.threshold = n
+	}
+}
+
+// WithWorkers sets the number of goroutines used. The default is GOMAXPROCS.
+func WithWorkers(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		c.workers = n
+	}
+}
+
+// ParallelSum splits numbers into contiguous chunks, sums each chunk in its own
+// goroutine and adds the partial sums. Small inputs are summed sequentially.
+func ParallelSum[T Number](numbers []T, opts ...ParallelOption) T {
+	cfg := parallelConfig{
+		threshold: DefaultParallelThreshold,
+		workers:   runtime.GOMAXPROCS(0),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	workers := min(cfg.workers, len(numbers))
+	if len(numbers) < cfg.threshold || workers < 2 {
+		return Sum(numbers)
+	}
+
+	partials := make([]T, workers)
+	chunk := (len(numbers) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for i := range workers {
+		start := i * chunk
+		end := min(start+chunk, len(numbers))
+		if start >= end {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partials[i] = Sum(numbers[start:end])
+		}()
+	}
+	wg.Wait()
+
+	return Sum(partials)
+}
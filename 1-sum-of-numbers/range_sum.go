@@ -0,0 +1,152 @@
+package main
+
+// PrefixSum answers range-sum queries over a fixed slice in O(1) after O(n) setup.
+type PrefixSum[T Number] struct {
+	prefix []T // prefix[i] is the sum of the first i values
+}
+
+// NewPrefixSum builds a PrefixSum over values. Later changes to values are not reflected.
+func NewPrefixSum[T Number](values []T) *PrefixSum[T] {
+	prefix := make([]T, len(values)+1)
+	for i, v := range values {
+		prefix[i+1] = prefix[i] + v
+	}
+	return &PrefixSum[T]{prefix: prefix}
+}
+
+// Len returns the number of values covered.
+func (p *PrefixSum[T]) Len() int {
+	return len(p.prefix) - 1
+}
+
+// RangeSum returns the sum of values[i:j]. It panics if the range is out of bounds,
+// just like slicing would.
+func (p *PrefixSum[T]) RangeSum(i, j int) T {
+	_ = p.prefix[i:j] // bounds check, including i > j
+	return p.prefix[j] - p.prefix[i]
+}
+
+// Fenwick is a binary indexed tree supporting point updates and range-sum
+// queries in O(log n).
+type Fenwick[T Number] struct {
+	tree []T // 1-based
+}
+
+// NewFenwick returns a Fenwick tree of n zero values.
+func NewFenwick[T Number](n int) *Fenwick[T] {
+	return &Fenwick[T]{tree: make([]T, n+1)}
+}
+
+// NewFenwickFrom builds a Fenwick tree over values in O(n).
+func NewFenwickFrom[T Number](values []T) *Fenwick[T] {
+	tree := make([]T, len(values)+1)
+	copy(tree[1:], values)
+	for i := 1; i < len(tree); i++ {
+		if parent := i + i&-i; parent < len(tree) {
+			tree[parent] += tree[i]
+		}
+	}
+	return &Fenwick[T]{tree: tree}
+}
+
+// Len returns the number of values in the tree.
+func (f *Fenwick[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Add adds delta to the value at index i.
+func (f *Fenwick[T]) Add(i int, delta T) {
+	if i < 0 || i >= f.Len() {
+		panic("fenwick: index out of range")
+	}
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// Set replaces the value at index i.
+func (f *Fenwick[T]) Set(i int, value T) {
+	f.Add(i, value-f.RangeSum(i, i+1))
+}
+
+// PrefixSum returns the sum of the first n values.
+func (f *Fenwick[T]) PrefixSum(n int) T {
+	if n < 0 || n > f.Len() {
+		panic("fenwick: index out of range")
+	}
+	var sum T
+	for ; n > 0; n -= n & -n {
+		sum += f.tree[n]
+	}
+	return sum
+}
+
+// RangeSum returns the sum of the values in [i, j).
+func (f *Fenwick[T]) RangeSum(i, j int) T {
+	if i > j {
+		panic("fenwick: invalid range")
+	}
+	return f.PrefixSum(j) - f.PrefixSum(i)
+}
+
+// Window keeps the running sum of the last size values pushed into it.
+type Window[T Number] struct {
+	buf  []T
+	next int
+	full bool
+	sum  T
+}
+
+// NewWindow returns a Window over the last size values. size must be positive.
+func NewWindow[T Number](size int) *Window[T] {
+	if size <= 0 {
+		panic("window: size must be positive")
+	}
+	return &Window[T]{buf: make([]T, size)}
+}
+
+// Push adds v, evicting the oldest value once the window is full, and returns the new sum.
+// For floats the running sum can drift over very long streams; Recompute resets it.
+func (w *Window[T]) Push(v T) T {
+	w.sum += v - w.buf[w.next]
+	w.buf[w.next] = v
+	w.next++
+	if w.next == len(w.buf) {
+		w.next = 0
+		w.full = true
+	}
+	return w.sum
+}
+
+// Sum returns the sum of the values currently in the window.
+func (w *Window[T]) Sum() T {
+	return w.sum
+}
+
+// Len returns how many values the window currently holds.
+func (w *Window[T]) Len() int {
+	if w.full {
+		return len(w.buf)
+	}
+	return w.next
+}
+
+// Recompute recalculates the sum from the buffered values, discarding accumulated rounding error.
+func (w *Window[T]) Recompute() T {
+	w.sum = Sum(w.buf)
+	return w.sum
+}
+
+// SlidingSum emits the sum of the last size values for every value received on in.
+// The returned channel is closed once in is closed.
+func SlidingSum[T Number](in <-chan T, size int) <-chan T {
+	w := NewWindow[T](size)
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for v := range in {
+			out <- w.Push(v)
+		}
+	}()
+	return out
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// ErrNoData is returned by statistics that are undefined for an empty input.
+var ErrNoData = errors.New("sum: no data")
+
+// Stats accumulates running statistics over a stream of values in constant memory.
+// The zero value is ready to use.
+type Stats struct {
+	count int
+	sum   neumaier[float64]
+	mean  float64
+	m2    float64 // Welford sum of squared deviations
+	min   float64
+	max   float64
+}
+
+// Add records x.
+func (s *Stats) Add(x float64) {
+	s.count++
+	s.sum.add(x)
+
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+
+	if s.count == 1 || x < s.min {
+		s.min = x
+	}
+	if s.count == 1 || x > s.max {
+		s.max = x
+	}
+}
+
+// Count returns the number of values recorded.
+func (s *Stats) Count() int {
+	return s.count
+}
+
+// Sum returns the compensated total of the values recorded.
+func (s *Stats) Sum() float64 {
+	return s.sum.total()
+}
+
+// Mean returns the arithmetic mean.
+func (s *Stats) Mean() (float64, error) {
+	if s.count == 0 {
+		return 0, ErrNoData
+	}
+	return s.mean, nil
+}
+
+// Min returns the smallest value recorded.
+func (s *Stats) Min() (float64, error) {
+	if s.count == 0 {
+		return 0, ErrNoData
+	}
+	return s.min, nil
+}
+
+// Max returns the largest value recorded.
+func (s *Stats) Max() (float64, error) {
+	if s.count == 0 {
+		return 0, ErrNoData
+	}
+	return s.max, nil
+}
+
+// StdDev returns the sample standard deviation, or the population standard
+// deviation when population is true.
+func (s *Stats) StdDev(population bool) (float64, error) {
+	if population {
+		if s.count == 0 {
+			return 0, ErrNoData
+		}
+		return math.Sqrt(s.m2 / float64(s.count)), nil
+	}
+	if s.count < 2 {
+		return 0, ErrNoData
+	}
+	return math.Sqrt(s.m2 / float64(s.count-1)), nil
+}
+
+// Percentile returns the p-th percentile (0 <= p <= 100) of values using
+// linear interpolation between the closest ranks. values is sorted in place.
+func Percentile(values []float64, p float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrNoData
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, errors.New("sum: percentile must be between 0 and 100")
+	}
+	slices.Sort(values)
+
+	rank := p / 100 * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	frac := rank - float64(lo)
+	return values[lo] + frac*(values[hi]-values[lo]), nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrOverflow is returned by the checked sums when the total does not fit in the result type.
+var ErrOverflow = errors.New("sum: integer overflow")
+
+// Number is any integer or floating-point type that can be summed.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Sum returns the total of numbers. Integer sums wrap on overflow; use CheckedSum to detect it.
+func Sum[T Number](numbers []T) T {
+	var sum T
+	for _, n := range numbers {
+		sum += n
+	}
+	return sum
+}
+
+// CheckedSum returns the total of numbers, or ErrOverflow if any partial sum overflows T.
+func CheckedSum[T constraints.Integer](numbers []T) (T, error) {
+	var sum T
+	for _, n := range numbers {
+		next, ok := checkedAdd(sum, n)
+		if !ok {
+			return 0, ErrOverflow
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// checkedAdd adds a and b, reporting false if the result wrapped around.
+func checkedAdd[T constraints.Integer](a, b T) (T, bool) {
+	s := a + b
+	if (b > 0 && s < a) || (b < 0 && s > a) {
+		return 0, false
+	}
+	return s, true
+}
+
+// SumReader sums the whitespace-separated integers read from r.
+// Input is consumed token by token, so memory use does not grow with the stream.
+func SumReader(r io.Reader) (int64, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+
+	var sum int64
+	for token := 1; scanner.Scan(); token++ {
+		n, err := strconv.ParseInt(scanner.Text(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("sum: token %d: %w", token, err)
+		}
+		next, ok := checkedAdd(sum, n)
+		if !ok {
+			return 0, ErrOverflow
+		}
+		sum = next
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
+
+// SumFloatReader sums the whitespace-separated floating-point numbers read from r.
+func SumFloatReader(r io.Reader) (float64, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+
+	var sum float64
+	for token := 1; scanner.Scan(); token++ {
+		n, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("sum: token %d: %w", token, err)
+		}
+		sum += n
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return sum, nil
+}
@@ -1,6 +1,19 @@
 package main
 
-import "testing"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
 
 func TestSum(t *testing.T) {
 	tests := []struct {
@@ -23,3 +36,546 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestSumFloat(t *testing.T) {
+	if got := Sum([]float64{1.5, 2.25, -0.75}); got != 3 {
+		t.Errorf("expected 3, got %v", got)
+	}
+}
+
+func TestCheckedSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int8
+		expected int8
+		wantErr  bool
+	}{
+		{"fits", []int8{100, 27}, 127, false},
+		{"positive overflow", []int8{100, 28}, 0, true},
+		{"negative overflow", []int8{-100, -29}, 0, true},
+		{"recovers after large partial", []int8{127, -1, 1}, 127, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CheckedSum(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrOverflow) {
+					t.Fatalf("expected ErrOverflow, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := CheckedSum([]uint8{200, 56}); !errors.Is(err, ErrOverflow) {
+		t.Errorf("expected ErrOverflow for unsigned input, got %v", err)
+	}
+}
+
+func TestSumReader(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int64
+		wantErr  error
+	}{
+		{"mixed whitespace", "1 2\n3\t4\n\n5\n", 15, nil},
+		{"empty input", "", 0, nil},
+		{"negative numbers", "-1\n-2 -3", -6, nil},
+		{"overflow", "9223372036854775807 1", 0, ErrOverflow},
+		{"bad token", "1 two 3", 0, strconv.ErrSyntax},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SumReader(strings.NewReader(tt.input))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSumFloatReader(t *testing.T) {
+	result, err := SumFloatReader(strings.NewReader("0.5 1.25\n-0.75 1e1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != 11 {
+		t.Errorf("expected 11, got %v", result)
+	}
+}
+
+// referenceSum computes the exact total of numbers with big.Float.
+func referenceSum(numbers []float64) *big.Float {
+	ref := new(big.Float).SetPrec(2048)
+	for _, n := range numbers {
+		ref.Add(ref, new(big.Float).SetFloat64(n))
+	}
+	return ref
+}
+
+// absError returns |got - ref| as a float64.
+func absError(got float64, ref *big.Float) float64 {
+	diff := new(big.Float).SetPrec(2048).SetFloat64(got)
+	diff.Sub(diff, ref)
+	f, _ := diff.Float64()
+	return math.Abs(f)
+}
+
+func TestFloatSumErrorBounds(t *testing.T) {
+	const n = 200000
+	rng := rand.New(rand.NewPCG(1, 2))
+	numbers := make([]float64, n)
+	var absSum float64
+	for i := range numbers {
+		numbers[i] = rng.NormFloat64() * math.Pow(10, float64(rng.IntN(11)-5))
+		absSum += math.Abs(numbers[i])
+	}
+	ref := referenceSum(numbers)
+
+	eps := math.Pow(2, -53)
+	compensated := (2*eps + 2*n*eps*eps) * absSum
+	tests := []struct {
+		name  string
+		mode  SumMode
+		bound float64
+	}{
+		{"naive", Naive, (n - 1) * eps * absSum},
+		{"kahan", Kahan, compensated},
+		{"neumaier", Neumaier, compensated},
+		{"pairwise", Pairwise, (pairwiseBlock + math.Ceil(math.Log2(n))) * eps * absSum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := absError(FloatSum(numbers, WithMode(tt.mode)), ref)
+			if err > tt.bound {
+				t.Errorf("error %g exceeds bound %g", err, tt.bound)
+			}
+		})
+	}
+}
+
+func TestFloatSumIllConditioned(t *testing.T) {
+	numbers := []float64{1, 1e100, 1, -1e100}
+	if got := FloatSum(numbers, WithMode(Neumaier)); got != 2 {
+		t.Errorf("neumaier: expected 2, got %v", got)
+	}
+	if got := FloatSum(numbers); got != 2 {
+		t.Errorf("default mode: expected 2, got %v", got)
+	}
+}
+
+func TestFloatSumFloat32Drift(t *testing.T) {
+	numbers := make([]float32, 1000000)
+	for i := range numbers {
+		numbers[i] = 0.1
+	}
+	ref := float64(float32(0.1)) * float64(len(numbers))
+
+	naiveErr := math.Abs(float64(Sum(numbers)) - ref)
+	for _, mode := range []SumMode{Kahan, Neumaier, Pairwise} {
+		err := math.Abs(float64(FloatSum(numbers, WithMode(mode))) - ref)
+		if err > naiveErr/100 {
+			t.Errorf("mode %d: error %g (naive %g)", mode, err, naiveErr)
+		}
+	}
+}
+
+func TestParallelSum(t *testing.T) {
+	numbers := make([]int, 1000003)
+	for i := range numbers {
+		numbers[i] = i%7 - 3
+	}
+	expected := Sum(numbers)
+
+	tests := []struct {
+		name string
+		opts []ParallelOption
+	}{
+		{"defaults", nil},
+		{"forced parallel", []ParallelOption{WithThreshold(0), WithWorkers(8)}},
+		{"single worker", []ParallelOption{WithThreshold(0), WithWorkers(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ParallelSum(numbers, tt.opts...); result != expected {
+				t.Errorf("expected %d, got %d", expected, result)
+			}
+		})
+	}
+
+	// More workers than elements: the worker count is clamped to the input length.
+	small := numbers[:10]
+	if result, expected := ParallelSum(small, WithThreshold(0), WithWorkers(16)), Sum(small); result != expected {
+		t.Errorf("more workers than elements: expected %d, got %d", expected, result)
+	}
+
+	if result := ParallelSum([]int{}, WithThreshold(0)); result != 0 {
+		t.Errorf("expected 0 for empty slice, got %d", result)
+	}
+}
+
+// BenchmarkParallelSum compares Sum and ParallelSum across input sizes to
+// locate the crossover point used for DefaultParallelThreshold.
+func BenchmarkParallelSum(b *testing.B) {
+	for _, size := range []int{1 << 10, 1 << 14, 1 << 16, 1 << 18, 1 << 20, 1 << 24} {
+		numbers := make([]int64, size)
+		for i := range numbers {
+			numbers[i] = int64(i)
+		}
+
+		b.Run(fmt.Sprintf("sequential/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				Sum(numbers)
+			}
+		})
+		b.Run(fmt.Sprintf("parallel/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				ParallelSum(numbers, WithThreshold(0))
+			}
+		})
+	}
+}
+
+func TestBigSum(t *testing.T) {
+	maxInt64 := big.NewInt(math.MaxInt64)
+	result := BigSum([]*big.Int{maxInt64, maxInt64, big.NewInt(2), nil})
+	expected, _ := new(big.Int).SetString("18446744073709551616", 10)
+	if result.Cmp(expected) != 0 {
+		t.Errorf("expected %s, got %s", expected, result)
+	}
+	if BigSum(nil).Sign() != 0 {
+		t.Errorf("expected 0 for empty input")
+	}
+}
+
+func TestBigRatSum(t *testing.T) {
+	result := BigRatSum([]*big.Rat{big.NewRat(1, 3), big.NewRat(1, 6), big.NewRat(1, 2)})
+	if result.Cmp(big.NewRat(1, 1)) != 0 {
+		t.Errorf("expected 1, got %s", result)
+	}
+}
+
+func TestBigDecimalSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected string
+		wantErr  bool
+	}{
+		{"cents", []string{"0.10", "0.20", "0.30"}, "0.60", false},
+		{"keeps input scale", []string{"1.50", "2.50"}, "4.00", false},
+		{"mixed scale", []string{"1", "0.005", "-0.5"}, "0.505", false},
+		{"beyond int64", []string{"9223372036854775807", "9223372036854775807.99"}, "18446744073709551614.99", false},
+		{"exponent notation", []string{"1e-3", "2E2"}, "200.001", false},
+		{"empty", nil, "0", false},
+		{"invalid", []string{"1.0", "abc"}, "", true},
+		{"fraction rejected", []string{"1/3"}, "", true},
+		{"bare point forms", []string{".5", "5."}, "5.5", false},
+		{"largest exponent", []string{"1e-1000", "-1e-1000"}, "0." + strings.Repeat("0", 1000), false},
+		{"hex float rejected", []string{"0x1p-2"}, "", true},
+		{"exponent too large", []string{"1e999999999"}, "", true},
+		{"exponent just too large", []string{"1e1001"}, "", true},
+		{"negative exponent too large", []string{"1E-1001"}, "", true},
+		{"sign only", []string{"-"}, "", true},
+		{"double sign", []string{"--1"}, "", true},
+		{"empty exponent", []string{"1e"}, "", true},
+		{"underscores rejected", []string{"1_000"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := BigDecimalSum(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPrefixSum(t *testing.T) {
+	values := []int{3, -1, 4, 1, -5, 9}
+	p := NewPrefixSum(values)
+	for i := 0; i <= len(values); i++ {
+		for j := i; j <= len(values); j++ {
+			if got, want := p.RangeSum(i, j), Sum(values[i:j]); got != want {
+				t.Errorf("RangeSum(%d, %d): expected %d, got %d", i, j, want, got)
+			}
+		}
+	}
+	if p.Len() != len(values) {
+		t.Errorf("expected length %d, got %d", len(values), p.Len())
+	}
+
+	for _, r := range [][2]int{{3, 2}, {-1, 2}, {0, len(values) + 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RangeSum(%d, %d): expected a panic", r[0], r[1])
+				}
+			}()
+			p.RangeSum(r[0], r[1])
+		}()
+	}
+}
+
+func TestFenwick(t *testing.T) {
+	values := []int{5, 2, -7, 0, 3, 8, -1}
+	f := NewFenwickFrom(values)
+
+	check := func(stage string) {
+		for i := 0; i <= len(values); i++ {
+			for j := i; j <= len(values); j++ {
+				if got, want := f.RangeSum(i, j), Sum(values[i:j]); got != want {
+					t.Errorf("%s: RangeSum(%d, %d): expected %d, got %d", stage, i, j, want, got)
+				}
+			}
+		}
+	}
+	check("initial")
+
+	f.Add(2, 10)
+	values[2] += 10
+	f.Set(5, -4)
+	values[5] = -4
+	check("after updates")
+
+	empty := NewFenwick[float64](3)
+	empty.Add(1, 2.5)
+	if got := empty.PrefixSum(3); got != 2.5 {
+		t.Errorf("expected 2.5, got %v", got)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	w := NewWindow[int](3)
+	expected := []int{1, 3, 6, 9, 12, 15}
+	for i, want := range expected {
+		if got := w.Push(i + 1); got != want {
+			t.Errorf("push %d: expected %d, got %d", i+1, want, got)
+		}
+	}
+	if w.Len() != 3 {
+		t.Errorf("expected length 3, got %d", w.Len())
+	}
+	if w.Recompute() != 15 {
+		t.Errorf("expected recomputed sum 15, got %d", w.Sum())
+	}
+}
+
+func TestSlidingSum(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for _, v := range []int{4, 4, 4, 1, 1} {
+			in <- v
+		}
+	}()
+
+	var got []int
+	for sum := range SlidingSum(in, 2) {
+		got = append(got, sum)
+	}
+	expected := []int{4, 8, 8, 5, 2}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+// decodeInt64s turns fuzz input into a slice of int64, eight bytes per value.
+func decodeInt64s(data []byte) []int64 {
+	numbers := make([]int64, len(data)/8)
+	for i := range numbers {
+		numbers[i] = int64(binary.LittleEndian.Uint64(data[i*8:]))
+	}
+	return numbers
+}
+
+// bigTotal returns the exact total of numbers.
+func bigTotal[T constraints.Integer](numbers []T) *big.Int {
+	total := new(big.Int)
+	for _, n := range numbers {
+		if n < 0 {
+			total.Add(total, big.NewInt(int64(n)))
+		} else {
+			total.Add(total, new(big.Int).SetUint64(uint64(n)))
+		}
+	}
+	return total
+}
+
+// checkedSumAgreesWithBig reports whether CheckedSum overflows exactly when some
+// prefix of numbers falls outside T's range, and otherwise matches the exact total.
+func checkedSumAgreesWithBig[T constraints.Integer](t *testing.T, numbers []T, lo, hi *big.Int) {
+	t.Helper()
+	overflows := false
+	for i := range numbers {
+		prefix := bigTotal(numbers[:i+1])
+		if prefix.Cmp(lo) < 0 || prefix.Cmp(hi) > 0 {
+			overflows = true
+			break
+		}
+	}
+
+	result, err := CheckedSum(numbers)
+	if overflows {
+		if !errors.Is(err, ErrOverflow) {
+			t.Fatalf("%v: expected ErrOverflow, got %v (%v)", numbers, result, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("%v: unexpected error: %v", numbers, err)
+	}
+	if bigTotal([]T{result}).Cmp(bigTotal(numbers)) != 0 {
+		t.Fatalf("%v: expected %s, got %v", numbers, bigTotal(numbers), result)
+	}
+}
+
+func FuzzSum(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(binary.LittleEndian.AppendUint64(nil, 1<<63))
+	f.Add(binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint64(nil, 1<<62), 1<<62))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		numbers := decodeInt64s(data)
+
+		// Wrapping addition is exact modulo 2^64.
+		wrapped := new(big.Int).SetInt64(Sum(numbers))
+		mod := new(big.Int).Lsh(big.NewInt(1), 64)
+		diff := new(big.Int).Sub(bigTotal(numbers), wrapped)
+		if diff.Mod(diff, mod).Sign() != 0 {
+			t.Fatalf("%v: Sum %d is not congruent to the exact total", numbers, Sum(numbers))
+		}
+
+		if ParallelSum(numbers, WithThreshold(0), WithWorkers(3)) != Sum(numbers) {
+			t.Fatalf("%v: ParallelSum disagrees with Sum", numbers)
+		}
+
+		checkedSumAgreesWithBig(t, numbers, big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64))
+	})
+}
+
+func FuzzCheckedSumSmall(f *testing.F) {
+	f.Add([]byte{100, 27})
+	f.Add([]byte{100, 28})
+	f.Add([]byte{0x80, 0xff})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		signed := make([]int8, len(data))
+		for i, b := range data {
+			signed[i] = int8(b)
+		}
+		checkedSumAgreesWithBig(t, signed, big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8))
+		checkedSumAgreesWithBig(t, data, big.NewInt(0), big.NewInt(math.MaxUint8))
+	})
+}
+
+func FuzzSumReader(f *testing.F) {
+	f.Add("1 2 3")
+	f.Add("9223372036854775807 1")
+	f.Add("-9223372036854775808\n-1")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		result, err := SumReader(strings.NewReader(input))
+		if err != nil {
+			return
+		}
+
+		var numbers []int64
+		for _, field := range strings.Fields(input) {
+			n, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				t.Fatalf("%q: SumReader accepted invalid token %q", input, field)
+			}
+			numbers = append(numbers, n)
+		}
+		if expected, err := CheckedSum(numbers); err != nil || expected != result {
+			t.Fatalf("%q: expected %d (%v), got %d", input, expected, err, result)
+		}
+	})
+}
+
+func TestSumProperties(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	randomInts := func() []int64 {
+		numbers := make([]int64, rng.IntN(50))
+		for i := range numbers {
+			numbers[i] = rng.Int64() - rng.Int64()
+		}
+		return numbers
+	}
+	const iterations = 1000
+
+	t.Run("commutativity", func(t *testing.T) {
+		for range iterations {
+			a := randomInts()
+			shuffled := slices.Clone(a)
+			rng.Shuffle(len(shuffled), func(i, j int) {
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+			})
+			if Sum(a) != Sum(shuffled) {
+				t.Fatalf("%v: sum changed after reordering", a)
+			}
+		}
+	})
+
+	t.Run("associativity", func(t *testing.T) {
+		for range iterations {
+			x, y, z := rng.Int64()-rng.Int64(), rng.Int64()-rng.Int64(), rng.Int64()-rng.Int64()
+			if Sum([]int64{Sum([]int64{x, y}), z}) != Sum([]int64{x, Sum([]int64{y, z})}) {
+				t.Fatalf("(%d+%d)+%d != %d+(%d+%d)", x, y, z, x, y, z)
+			}
+		}
+	})
+
+	t.Run("concatenation", func(t *testing.T) {
+		for range iterations {
+			a, b := randomInts(), randomInts()
+			if Sum(slices.Concat(a, b)) != Sum(a)+Sum(b) {
+				t.Fatalf("sum of %v ++ %v differs from sum of sums", a, b)
+			}
+		}
+	})
+
+	t.Run("checked matches big.Int", func(t *testing.T) {
+		for range iterations {
+			a := randomInts()
+			// Shrink some inputs so both overflowing and non-overflowing cases occur.
+			if rng.IntN(2) == 0 {
+				for i := range a {
+					a[i] >>= 8
+				}
+			}
+			checkedSumAgreesWithBig(t, a, big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64))
+		}
+	})
+}
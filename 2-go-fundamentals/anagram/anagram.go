@@ -0,0 +1,149 @@
+// Package anagram groups words into anagram classes and measures how similar
+// two strings are, for spelling suggestions and word games.
+package anagram
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Key returns the signature shared by all anagrams of s: its letters and
+// digits, lowercased and sorted. Spaces and punctuation are ignored, so
+// "Dormitory" and "dirty room" have the same key. Accented letters are
+// distinct from plain ones.
+func Key(s string) string {
+	var runes []rune
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			runes = append(runes, r)
+		}
+	}
+	slices.Sort(runes)
+	return string(runes)
+}
+
+// IsAnagram reports whether a and b are anagrams of each other. A string is
+// not an anagram of itself, nor of the same word written with different case
+// or punctuation.
+func IsAnagram(a, b string) bool {
+	ka := Key(a)
+	return ka != "" && ka == Key(b) && normalize(a) != normalize(b)
+}
+
+// normalize returns the lowercased letters and digits of s in their original order.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return r
+		}
+		return -1
+	}, strings.ToLower(s))
+}
+
+// Dictionary indexes words by their anagram Key. The zero value is not
+// usable; create one with NewDictionary or LoadDictionary.
+type Dictionary struct {
+	classes map[string][]string // key -> sorted, distinct words
+	size    int
+}
+
+// NewDictionary returns a dictionary holding words.
+func NewDictionary(words ...string) *Dictionary {
+	d := &Dictionary{classes: make(map[string][]string)}
+	for _, w := range words {
+		d.Add(w)
+	}
+	return d
+}
+
+// LoadDictionary reads a word list with one word or phrase per line.
+// Blank lines and lines starting with '#' are ignored.
+func LoadDictionary(r io.Reader) (*Dictionary, error) {
+	d := NewDictionary()
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		d.Add(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// LoadDictionaryFile reads a word list from the named file. See LoadDictionary.
+func LoadDictionaryFile(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadDictionary(f)
+}
+
+// Add inserts word, lowercased, into the dictionary. Words without letters or
+// digits and words already present are ignored.
+func (d *Dictionary) Add(word string) {
+	word = strings.ToLower(strings.TrimSpace(word))
+	key := Key(word)
+	if key == "" {
+		return
+	}
+	class := d.classes[key]
+	i, found := slices.BinarySearch(class, word)
+	if found {
+		return
+	}
+	d.classes[key] = slices.Insert(class, i, word)
+	d.size++
+}
+
+// Len returns the number of words in the dictionary.
+func (d *Dictionary) Len() int {
+	return d.size
+}
+
+// Contains reports whether word, compared case-insensitively, is in the dictionary.
+func (d *Dictionary) Contains(word string) bool {
+	word = strings.ToLower(strings.TrimSpace(word))
+	_, found := slices.BinarySearch(d.classes[Key(word)], word)
+	return found
+}
+
+// Anagrams returns the dictionary words that are anagrams of query, sorted.
+// query itself is never included.
+func (d *Dictionary) Anagrams(query string) []string {
+	var out []string
+	for _, w := range d.classes[Key(query)] {
+		if normalize(w) != normalize(query) {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+// Classes returns the anagram classes with at least minSize words, largest
+// first and then in order of their first word. Each class is sorted.
+func (d *Dictionary) Classes(minSize int) [][]string {
+	var out [][]string
+	for _, class := range d.classes {
+		if len(class) >= minSize {
+			out = append(out, slices.Clone(class))
+		}
+	}
+	slices.SortFunc(out, func(a, b []string) int {
+		if c := cmp.Compare(len(b), len(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a[0], b[0])
+	})
+	return out
+}
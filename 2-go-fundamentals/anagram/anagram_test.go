@@ -0,0 +1,111 @@
+package anagram
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"listen", "eilnst"},
+		{"Silent", "eilnst"},
+		{"Dormitory", "dimoorrty"},
+		{"dirty room!", "dimoorrty"},
+		{"ሰላም", "ላምሰ"},
+		{"--", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Key(tt.input); got != tt.expected {
+			t.Errorf("Key(%q): expected %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestIsAnagram(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"listen", "silent", true},
+		{"Dormitory", "dirty room", true},
+		{"The eyes", "They see", true},
+		{"listen", "Listen", false},
+		{"listen", "listens", false},
+		{"", "", false},
+		{"abc", "abd", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsAnagram(tt.a, tt.b); got != tt.expected {
+			t.Errorf("IsAnagram(%q, %q): expected %v, got %v", tt.a, tt.b, tt.expected, got)
+		}
+	}
+}
+
+func TestDictionary(t *testing.T) {
+	d := NewDictionary("listen", "silent", "enlist", "Tinsel", "google", "stop", "pots", "tops", "spot", "post", "opts", "Listen", "!!")
+
+	if d.Len() != 11 {
+		t.Errorf("expected 11 words, got %d", d.Len())
+	}
+	if !d.Contains("LISTEN") || d.Contains("banana") {
+		t.Error("Contains: expected LISTEN to be found and banana not")
+	}
+
+	if got, expected := d.Anagrams("Listen"), []string{"enlist", "silent", "tinsel"}; !slices.Equal(got, expected) {
+		t.Errorf("Anagrams: expected %v, got %v", expected, got)
+	}
+	if got := d.Anagrams("Google"); len(got) != 0 {
+		t.Errorf("Anagrams: expected none for google, got %v", got)
+	}
+	if got, expected := d.Anagrams("inlets"), []string{"enlist", "listen", "silent", "tinsel"}; !slices.Equal(got, expected) {
+		t.Errorf("Anagrams of a word not in the dictionary: expected %v, got %v", expected, got)
+	}
+
+	expected := [][]string{
+		{"opts", "post", "pots", "spot", "stop", "tops"},
+		{"enlist", "listen", "silent", "tinsel"},
+	}
+	if got := d.Classes(2); !slices.EqualFunc(got, expected, slices.Equal) {
+		t.Errorf("Classes(2): expected %v, got %v", expected, got)
+	}
+	if got := d.Classes(1); len(got) != 3 {
+		t.Errorf("Classes(1): expected 3 classes, got %v", got)
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	input := "# word list\nevil\n\n  Live \nvile\ndirty room\n"
+	d, err := LoadDictionary(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := d.Anagrams("veil"), []string{"evil", "live", "vile"}; !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got, expected := d.Anagrams("dormitory"), []string{"dirty room"}; !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err = LoadDictionaryFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 4 {
+		t.Errorf("expected 4 words, got %d", d.Len())
+	}
+	if _, err := LoadDictionaryFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,140 @@
+package anagram
+
+// The metrics below compare strings rune by rune and are case-sensitive;
+// lowercase both sides first for a case-insensitive comparison.
+
+// Levenshtein returns the minimum number of single-rune insertions, deletions
+// and substitutions that turn a into b.
+func Levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
+
+// Damerau returns the Damerau-Levenshtein distance between a and b: like
+// Levenshtein, but swapping two adjacent runes also counts as one edit, so
+// "form" and "from" are 1 apart instead of 2. Unlike the simpler optimal
+// string alignment distance, substrings may be edited after a swap, which
+// keeps it a true metric ("ca" to "abc" is 2).
+func Damerau(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	inf := len(s) + len(t)
+
+	// d is offset by one in both dimensions so that row and column 0 can
+	// hold the sentinel inf.
+	d := make([][]int, len(s)+2)
+	for i := range d {
+		d[i] = make([]int, len(t)+2)
+	}
+	d[0][0] = inf
+	for i := 0; i <= len(s); i++ {
+		d[i+1][0] = inf
+		d[i+1][1] = i
+	}
+	for j := 0; j <= len(t); j++ {
+		d[0][j+1] = inf
+		d[1][j+1] = j
+	}
+
+	lastRow := make(map[rune]int) // last row of s in which each rune appeared
+	for i := 1; i <= len(s); i++ {
+		lastMatchCol := 0
+		for j := 1; j <= len(t); j++ {
+			k := lastRow[t[j-1]]
+			l := lastMatchCol
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+				lastMatchCol = j
+			}
+			d[i+1][j+1] = min(
+				d[i][j]+cost,              // substitution
+				d[i+1][j]+1,               // insertion
+				d[i][j+1]+1,               // deletion
+				d[k][l]+(i-k-1)+1+(j-l-1), // transposition
+			)
+		}
+		lastRow[s[i-1]] = i
+	}
+	return d[len(s)+1][len(t)+1]
+}
+
+// Jaro returns the Jaro similarity of a and b, from 0 (nothing in common) to
+// 1 (identical). Two empty strings are identical.
+func Jaro(a, b string) float64 {
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 && len(t) == 0 {
+		return 1
+	}
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	window = max(window, 0)
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		lo, hi := max(0, i-window), min(len(t), i+window+1)
+		for j := lo; j < hi; j++ {
+			if !tMatched[j] && s[i] == t[j] {
+				sMatched[i], tMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	// Count matched runes that appear in a different order in the two strings.
+	transpositions := 0
+	j := 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// JaroWinkler returns the Jaro-Winkler similarity of a and b, from 0 to 1.
+// It raises the Jaro similarity of strings sharing a prefix of up to four
+// runes, which suits typos since they are rarer at the start of a word.
+// The corresponding distance is 1 - JaroWinkler(a, b).
+func JaroWinkler(a, b string) float64 {
+	const scaling = 0.1
+	sim := Jaro(a, b)
+	s, t := []rune(a), []rune(b)
+	prefix := 0
+	for prefix < min(len(s), len(t), 4) && s[prefix] == t[prefix] {
+		prefix++
+	}
+	return sim + float64(prefix)*scaling*(1-sim)
+}
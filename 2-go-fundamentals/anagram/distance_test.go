@@ -0,0 +1,96 @@
+package anagram
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"form", "from", 2},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"same", "same", 0},
+		{"héllo", "hello", 1},
+	}
+
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Levenshtein(%q, %q): expected %d, got %d", tt.a, tt.b, tt.expected, got)
+		}
+	}
+}
+
+func TestDamerau(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"form", "from", 1},
+		{"ca", "abc", 2},
+		{"kitten", "sitting", 3},
+		{"teh", "the", 1},
+		{"abcdef", "badcfe", 3},
+		{"", "ab", 2},
+		{"ሰላም", "ላሰም", 1},
+	}
+
+	for _, tt := range tests {
+		if got := Damerau(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Damerau(%q, %q): expected %d, got %d", tt.a, tt.b, tt.expected, got)
+		}
+		if got := Damerau(tt.b, tt.a); got != tt.expected {
+			t.Errorf("Damerau(%q, %q): expected %d, got %d", tt.b, tt.a, tt.expected, got)
+		}
+	}
+}
+
+func TestJaroWinkler(t *testing.T) {
+	tests := []struct {
+		a, b              string
+		jaro, jaroWinkler float64
+	}{
+		{"MARTHA", "MARHTA", 0.944444, 0.961111},
+		{"DWAYNE", "DUANE", 0.822222, 0.840000},
+		{"DIXON", "DICKSONX", 0.766667, 0.813333},
+		{"abc", "xyz", 0, 0},
+		{"", "", 1, 1},
+		{"a", "", 0, 0},
+		{"same", "same", 1, 1},
+	}
+
+	for _, tt := range tests {
+		if got := Jaro(tt.a, tt.b); math.Abs(got-tt.jaro) > 1e-6 {
+			t.Errorf("Jaro(%q, %q): expected %.6f, got %.6f", tt.a, tt.b, tt.jaro, got)
+		}
+		if got := JaroWinkler(tt.a, tt.b); math.Abs(got-tt.jaroWinkler) > 1e-6 {
+			t.Errorf("JaroWinkler(%q, %q): expected %.6f, got %.6f", tt.a, tt.b, tt.jaroWinkler, got)
+		}
+	}
+}
+
+func FuzzDamerau(f *testing.F) {
+	f.Add("form", "from")
+	f.Add("ca", "abc")
+	f.Fuzz(func(t *testing.T, a, b string) {
+		if !utf8.ValidString(a) || !utf8.ValidString(b) {
+			t.Skip() // invalid bytes all decode to U+FFFD
+		}
+		d := Damerau(a, b)
+		if l := Levenshtein(a, b); d > l {
+			t.Errorf("Damerau(%q, %q) = %d exceeds Levenshtein %d", a, b, d, l)
+		}
+		if r := Damerau(b, a); d != r {
+			t.Errorf("Damerau is not symmetric for %q, %q: %d vs %d", a, b, d, r)
+		}
+		if (d == 0) != (a == b) {
+			t.Errorf("Damerau(%q, %q) = %d", a, b, d)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package anagram
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Suggestion is a known word close to a query.
+type Suggestion struct {
+	Word       string  `json:"word"`
+	Distance   int     `json:"distance"`   // Damerau distance to the query
+	Similarity float64 `json:"similarity"` // Jaro-Winkler similarity to the query
+	Count      int     `json:"count"`      // occurrences of Word in the counts
+}
+
+type config struct {
+	maxDistance int
+	minCount    int
+}
+
+// Option configures a Suggester.
+type Option func(*config)
+
+// WithMaxDistance sets the largest Damerau distance a suggestion may have.
+// The default is 2.
+func WithMaxDistance(n int) Option {
+	return func(c *config) {
+		c.maxDistance = n
+	}
+}
+
+// WithMinCount ignores words seen fewer than n times, which keeps one-off
+// typos in the counts from being suggested. The default is 1.
+func WithMinCount(n int) Option {
+	return func(c *config) {
+		c.minCount = n
+	}
+}
+
+// Suggester proposes corrections for misspelled words from a table of word
+// counts, such as the result of wordfreq.WordFrequencyCount or
+// (*wordfreq.Counter).Counts. It is safe for concurrent use.
+type Suggester struct {
+	cfg    config
+	counts map[string]int
+	byLen  map[int][]string // words grouped by length in runes
+}
+
+// NewSuggester returns a Suggester over counts. Words are compared lowercased;
+// counts of words differing only in case are added together.
+func NewSuggester(counts map[string]int, opts ...Option) *Suggester {
+	cfg := config{maxDistance: 2, minCount: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Suggester{cfg: cfg, counts: make(map[string]int), byLen: make(map[int][]string)}
+	for word, n := range counts {
+		s.counts[strings.ToLower(word)] += n
+	}
+	for word, n := range s.counts {
+		if n < cfg.minCount {
+			delete(s.counts, word)
+			continue
+		}
+		l := utf8.RuneCountInString(word)
+		s.byLen[l] = append(s.byLen[l], word)
+	}
+	return s
+}
+
+// Suggest returns up to k known words within the maximum distance of word,
+// best first: by distance, then by count, then by Jaro-Winkler similarity.
+// word itself is included, at distance 0, if it is known. k <= 0 returns
+// every candidate.
+func (s *Suggester) Suggest(word string, k int) []Suggestion {
+	word = strings.ToLower(word)
+	n := utf8.RuneCountInString(word)
+
+	var out []Suggestion
+	// Words whose length differs by more than maxDistance cannot be close
+	// enough, so only the neighbouring length buckets are scanned.
+	for l := max(n-s.cfg.maxDistance, 0); l <= n+s.cfg.maxDistance; l++ {
+		for _, candidate := range s.byLen[l] {
+			d := Damerau(word, candidate)
+			if d > s.cfg.maxDistance {
+				continue
+			}
+			out = append(out, Suggestion{
+				Word:       candidate,
+				Distance:   d,
+				Similarity: JaroWinkler(word, candidate),
+				Count:      s.counts[candidate],
+			})
+		}
+	}
+
+	slices.SortFunc(out, func(a, b Suggestion) int {
+		if c := cmp.Compare(a.Distance, b.Distance); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Similarity, a.Similarity); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	if k > 0 && len(out) > k {
+		out = out[:k]
+	}
+	return out
+}
+
+// DidYouMean returns the best correction for word and true, or "" and false
+// if word is already known or nothing is close enough.
+func (s *Suggester) DidYouMean(word string) (string, bool) {
+	if s.counts[strings.ToLower(word)] > 0 {
+		return "", false
+	}
+	suggestions := s.Suggest(word, 1)
+	if len(suggestions) == 0 {
+		return "", false
+	}
+	return suggestions[0].Word, true
+}
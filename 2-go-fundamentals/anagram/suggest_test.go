@@ -0,0 +1,89 @@
+package anagram
+
+import (
+	"testing"
+
+	"go-fundamentals/wordfreq"
+)
+
+const corpus = `The quick brown fox jumps over the lazy dog. The dog sleeps.
+A brown bear and a brown fox met near the river. The bear was quiet.`
+
+func TestSuggest(t *testing.T) {
+	s := NewSuggester(wordfreq.WordFrequencyCount(corpus))
+
+	got := s.Suggest("brwon", 3)
+	if len(got) == 0 || got[0].Word != "brown" || got[0].Distance != 1 || got[0].Count != 3 {
+		t.Fatalf("expected brown at distance 1 with count 3 first, got %+v", got)
+	}
+
+	// "quiet" is one edit from "quiel" and "quick" two.
+	got = s.Suggest("quiel", 0)
+	words := make([]string, len(got))
+	for i, sg := range got {
+		words[i] = sg.Word
+	}
+	if len(words) < 2 || words[0] != "quiet" || words[1] != "quick" {
+		t.Errorf("expected quiet then quick, got %v", words)
+	}
+
+	if got := s.Suggest("elephant", 0); len(got) != 0 {
+		t.Errorf("expected no suggestions, got %+v", got)
+	}
+}
+
+func TestSuggestRanksByCount(t *testing.T) {
+	s := NewSuggester(map[string]int{"cat": 10, "car": 50, "cap": 1, "Car": 5})
+	got := s.Suggest("cax", 0)
+	expected := []string{"car", "cat", "cap"}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %+v", expected, got)
+	}
+	for i, w := range expected {
+		if got[i].Word != w {
+			t.Errorf("position %d: expected %q, got %q", i, w, got[i].Word)
+		}
+	}
+	if got[0].Count != 55 {
+		t.Errorf("expected counts of car and Car to be merged to 55, got %d", got[0].Count)
+	}
+}
+
+func TestSuggesterOptions(t *testing.T) {
+	counts := map[string]int{"receive": 20, "recieve": 1, "believe": 8}
+
+	s := NewSuggester(counts, WithMinCount(2))
+	if w, ok := s.DidYouMean("recieve"); !ok || w != "receive" {
+		t.Errorf("expected receive, got %q, %v", w, ok)
+	}
+
+	s = NewSuggester(counts, WithMaxDistance(1))
+	if got := s.Suggest("beleive", 0); len(got) != 1 || got[0].Word != "believe" {
+		t.Errorf("expected only believe within distance 1, got %+v", got)
+	}
+	if got := s.Suggest("bereave", 0); len(got) != 0 {
+		t.Errorf("expected nothing within distance 1, got %+v", got)
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	s := NewSuggester(wordfreq.WordFrequencyCount(corpus))
+
+	tests := []struct {
+		word     string
+		expected string
+		ok       bool
+	}{
+		{"teh", "the", true},
+		{"Jumsp", "jumps", true},
+		{"fox", "", false},
+		{"xylophone", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := s.DidYouMean(tt.word)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("DidYouMean(%q): expected %q, %v, got %q, %v", tt.word, tt.expected, tt.ok, got, ok)
+		}
+	}
+}
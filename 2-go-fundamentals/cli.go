@@ -0,0 +1,376 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go-fundamentals/palindrome"
+	"go-fundamentals/server"
+	"go-fundamentals/wordfreq"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const usage = `Usage: go-fundamentals <command> [flags] [args...]
+
+Commands:
+  wordfreq     count words in files (or stdin)
+  palindrome   check text for palindromes
+  serve        start the HTTP text-analysis service
+  repl         start the interactive shell
+
+Run "go-fundamentals <command> -h" for the flags of a command.
+
+Exit status:
+  0  success (palindrome: the text is a palindrome, or palindromes were found)
+  1  palindrome: not a palindrome, or nothing found
+  2  usage or I/O error
+`
+
+// Exit codes follow grep: 0 for a positive result, 1 for a negative one, 2 for errors.
+const (
+	exitOK       = 0
+	exitNegative = 1
+	exitError    = 2
+)
+
+// run executes the CLI with args (excluding the program name) and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitError
+	}
+
+	switch args[0] {
+	case "wordfreq":
+		return runWordFreq(args[1:], stdin, stdout, stderr)
+	case "palindrome":
+		return runPalindrome(args[1:], stdin, stdout, stderr)
+	case "serve":
+		return runServe(args[1:], stderr)
+	case "repl":
+		return runREPL(args[1:], stdin, stdout, stderr)
+	case "-h", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "go-fundamentals: unknown command %q\n\n%s", args[0], usage)
+		return exitError
+	}
+}
+
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: go-fundamentals %s\n\nFlags:\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args and reports the exit code to use if parsing stopped the command.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitError, false
+	}
+	return 0, true
+}
+
+// openInputs calls fn with each named file, or with stdin when names is empty.
+// "-" also stands for stdin.
+func openInputs(names []string, stdin io.Reader, fn func(name string, r io.Reader) error) error {
+	if len(names) == 0 {
+		return fn("-", stdin)
+	}
+	for _, name := range names {
+		if name == "-" {
+			if err := fn(name, stdin); err != nil {
+				return err
+			}
+			continue
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = fn(name, f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func runWordFreq(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("wordfreq", "wordfreq [flags] [file...]", stderr)
+	format := fs.String("format", "table", "output `format`: table, csv, json or markdown")
+	sortBy := fs.String("sort", "count", "sort `order`: count or word")
+	top := fs.Int("top", 0, "only print the `n` most frequent entries (0 for all)")
+	stopWords := fs.String("stopwords", "", "drop stop words from `list`: \"english\" or a file with one word per line")
+	stem := fs.Bool("stem", false, "reduce English words to their Porter stem")
+	minLength := fs.Int("min-length", 0, "drop words shorter than `n` characters")
+	ngram := fs.Int("ngram", 1, "count sequences of `n` words instead of single words")
+	fold := fs.Bool("fold", false, "use full Unicode case folding instead of lowercasing")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *ngram < 1 {
+		fmt.Fprintf(stderr, "go-fundamentals: invalid -ngram %d (want 1 or more)\n", *ngram)
+		return exitError
+	}
+	outFormat, err := wordfreq.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+	order, err := wordfreq.ParseSortOrder(*sortBy)
+	if err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+
+	opts := []wordfreq.Option{wordfreq.WithMinLength(*minLength)}
+	if *fold {
+		opts = append(opts, wordfreq.WithCaseMode(wordfreq.CaseFold))
+	}
+	if *stem {
+		opts = append(opts, wordfreq.WithStemmer(wordfreq.PorterStemmer{}))
+	}
+	switch *stopWords {
+	case "":
+	case "english":
+		opts = append(opts, wordfreq.WithStopWords(wordfreq.EnglishStopWords...))
+	default:
+		words, err := wordfreq.LoadStopWordsFile(*stopWords)
+		if err != nil {
+			fmt.Fprintln(stderr, "go-fundamentals:", err)
+			return exitError
+		}
+		opts = append(opts, wordfreq.WithStopWords(words...))
+	}
+
+	frequency := make(map[string]int)
+	err = openInputs(fs.Args(), stdin, func(_ string, r io.Reader) error {
+		if *ngram > 1 {
+			grams, err := wordfreq.NGramCountReader(r, *ngram, opts...)
+			if err != nil {
+				return err
+			}
+			for gram, n := range grams {
+				frequency[gram] += n
+			}
+			return nil
+		}
+		c := wordfreq.NewCounter(opts...)
+		if _, err := c.ReadFrom(r); err != nil {
+			return err
+		}
+		for word, n := range c.Counts() {
+			frequency[word] += n
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+
+	counts := wordfreq.Sorted(frequency, wordfreq.ByCount)
+	if *top > 0 && *top < len(counts) {
+		counts = counts[:*top]
+	}
+	wordfreq.SortCounts(counts, order)
+	if err := wordfreq.WriteCounts(stdout, counts, outFormat); err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+	return exitOK
+}
+
+func runPalindrome(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("palindrome", "palindrome [flags] [text...]\n       go-fundamentals palindrome -scan words|lines|sentences [flags] [file...]", stderr)
+	scan := fs.String("scan", "", "scan files (or stdin) for palindromic `unit`s: words, lines or sentences")
+	longest := fs.Bool("longest", false, "print the longest palindromic substring of the text")
+	minLength := fs.Int("min-length", 2, "with -scan, the minimum palindrome `length` in characters")
+	format := fs.String("format", "text", "output `format`: text or json")
+	caseSensitive := fs.Bool("case-sensitive", false, "treat upper and lower case as different")
+	keepAccents := fs.Bool("keep-accents", false, "treat accented letters as different from plain ones")
+	keepPunct := fs.Bool("keep-punct", false, "compare punctuation and symbols too")
+	keepSpace := fs.Bool("keep-space", false, "compare whitespace too")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "go-fundamentals: unknown format %q (want text or json)\n", *format)
+		return exitError
+	}
+	var opts []palindrome.Option
+	if *caseSensitive {
+		opts = append(opts, palindrome.CaseSensitive())
+	}
+	if *keepAccents {
+		opts = append(opts, palindrome.KeepAccents())
+	}
+	if *keepPunct {
+		opts = append(opts, palindrome.KeepPunctuation())
+	}
+	if *keepSpace {
+		opts = append(opts, palindrome.KeepWhitespace())
+	}
+
+	if *scan != "" {
+		return scanPalindromes(*scan, *minLength, *format, fs.Args(), opts, stdin, stdout, stderr)
+	}
+
+	var text string
+	if fs.NArg() > 0 {
+		text = strings.Join(fs.Args(), " ")
+	} else {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintln(stderr, "go-fundamentals:", err)
+			return exitError
+		}
+		text = strings.TrimRight(string(data), "\r\n")
+	}
+	if strings.TrimSpace(text) == "" {
+		fmt.Fprintln(stderr, "go-fundamentals: no text to check")
+		return exitError
+	}
+
+	if *longest {
+		m := palindrome.Longest(text, opts...)
+		if err := writeResult(stdout, *format, m, m.Text); err != nil {
+			fmt.Fprintln(stderr, "go-fundamentals:", err)
+			return exitError
+		}
+		if m.Length == 0 {
+			return exitNegative
+		}
+		return exitOK
+	}
+
+	ok := palindrome.IsPalindrome(text, opts...)
+	result := struct {
+		Text       string `json:"text"`
+		Palindrome bool   `json:"palindrome"`
+	}{text, ok}
+	if err := writeResult(stdout, *format, result, fmt.Sprint(ok)); err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+	if !ok {
+		return exitNegative
+	}
+	return exitOK
+}
+
+func scanPalindromes(unit string, minLength int, format string, files []string, opts []palindrome.Option, stdin io.Reader, stdout, stderr io.Writer) int {
+	modes := map[string]palindrome.ScanMode{
+		"words":     palindrome.ScanWords,
+		"lines":     palindrome.ScanLines,
+		"sentences": palindrome.ScanSentences,
+	}
+	mode, ok := modes[unit]
+	if !ok {
+		fmt.Fprintf(stderr, "go-fundamentals: unknown scan unit %q (want words, lines or sentences)\n", unit)
+		return exitError
+	}
+
+	type fileFinding struct {
+		File string `json:"file"`
+		palindrome.Finding
+	}
+	findings := []fileFinding{}
+	err := openInputs(files, stdin, func(name string, r io.Reader) error {
+		s := palindrome.NewScanner(r, mode, opts...)
+		s.MinLength = minLength
+		for s.Scan() {
+			f := s.Finding()
+			if format == "text" {
+				fmt.Fprintf(stdout, "%s:%d:%d: %s\n", name, f.Line, f.Column, f.Text)
+			}
+			findings = append(findings, fileFinding{File: name, Finding: f})
+		}
+		return s.Err()
+	})
+	if err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+
+	if format == "json" {
+		if err := writeJSON(stdout, findings); err != nil {
+			fmt.Fprintln(stderr, "go-fundamentals:", err)
+			return exitError
+		}
+	}
+	if len(findings) == 0 {
+		return exitNegative
+	}
+	return exitOK
+}
+
+func runServe(args []string, stderr io.Writer) int {
+	fs := newFlagSet("serve", "serve [flags]", stderr)
+	addr := fs.String("addr", ":8080", "listen `address`")
+	maxBody := fs.Int64("max-body", 1<<20, "maximum JSON request size in `bytes`")
+	maxUpload := fs.Int64("max-upload", 64<<20, "maximum text/plain upload size in `bytes`")
+	maxBatch := fs.Int("max-batch", 100, "maximum number of requests in one batch")
+	maxFindings := fs.Int("max-findings", 10000, "maximum number of findings returned by one palindrome scan")
+	timeout := fs.Duration("timeout", time.Minute, "maximum `duration` for reading a request or writing a response")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	errorLog := log.New(stderr, "", log.LstdFlags)
+	handler := server.New(server.Config{
+		MaxBodyBytes:   *maxBody,
+		MaxUploadBytes: *maxUpload,
+		MaxBatchItems:  *maxBatch,
+		MaxFindings:    *maxFindings,
+		ErrorLog:       errorLog,
+	})
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ErrorLog:          errorLog,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      *timeout,
+		IdleTimeout:       2 * time.Minute,
+	}
+	fmt.Fprintf(stderr, "listening on %s\n", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// writeResult prints text, or v as JSON when format is "json".
+func writeResult(w io.Writer, format string, v any, text string) error {
+	if format == "json" {
+		return writeJSON(w, v)
+	}
+	_, err := fmt.Fprintln(w, text)
+	return err
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	book := filepath.Join(dir, "book.txt")
+	if err := os.WriteFile(book, []byte("Anna met Otto.\nStep on no pets\nThe end\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+		code     int
+	}{
+		{"wordfreq stdin csv", []string{"wordfreq", "-format", "csv"}, "b a b", "word,count\nb,2\na,1", 0},
+		{"wordfreq sort by word", []string{"wordfreq", "-format", "csv", "-sort", "word"}, "b a b", "word,count\na,1\nb,2", 0},
+		{"wordfreq top", []string{"wordfreq", "-format", "csv", "-top", "1"}, "x y y z z z", "word,count\nz,3", 0},
+		{"wordfreq stop words and stemming", []string{"wordfreq", "-format", "csv", "-stopwords", "english", "-stem"}, "The runners are running", "word,count\nrun,1\nrunner,1", 0},
+		{"wordfreq bigrams", []string{"wordfreq", "-format", "csv", "-ngram", "2"}, "a b a b", "word,count\na b,2\nb a,1", 0},
+		{"wordfreq bigrams across lines", []string{"wordfreq", "-format", "csv", "-ngram", "2"}, "a b\na\nb\n", "word,count\na b,2\nb a,1", 0},
+		{"wordfreq file", []string{"wordfreq", "-format", "csv", "-top", "1", book}, "", "word,count\nanna,1", 0},
+		{"wordfreq missing file", []string{"wordfreq", filepath.Join(dir, "missing")}, "", "", 2},
+		{"wordfreq bad format", []string{"wordfreq", "-format", "xml"}, "", "", 2},
+		{"wordfreq zero ngram", []string{"wordfreq", "-ngram", "0"}, "a b", "", 2},
+		{"wordfreq negative ngram", []string{"wordfreq", "-ngram", "-2"}, "a b", "", 2},
+		{"palindrome args", []string{"palindrome", "Never", "odd", "or", "even"}, "", "true", 0},
+		{"palindrome not", []string{"palindrome", "hello"}, "", "false", 1},
+		{"palindrome stdin", []string{"palindrome"}, "Step on no pets\n", "true", 0},
+		{"palindrome empty stdin", []string{"palindrome"}, "", "", 2},
+		{"palindrome blank argument", []string{"palindrome", " "}, "", "", 2},
+		{"palindrome case sensitive", []string{"palindrome", "-case-sensitive", "Abba"}, "", "false", 1},
+		{"palindrome longest", []string{"palindrome", "-longest", "xyzracecarq"}, "", "racecar", 0},
+		{"palindrome scan", []string{"palindrome", "-scan", "words", "-min-length", "3", book}, "", book + ":1:1: Anna\n" + book + ":1:10: Otto", 0},
+		{"palindrome scan lines", []string{"palindrome", "-scan", "lines"}, "abc\nStep on no pets\n", "-:2:1: Step on no pets", 0},
+		{"palindrome scan nothing", []string{"palindrome", "-scan", "sentences"}, "Nothing here.", "", 1},
+		{"palindrome bad unit", []string{"palindrome", "-scan", "pages"}, "", "", 2},
+		{"unknown command", []string{"anagram"}, "", "", 2},
+		{"no command", nil, "", "", 2},
+		{"help", []string{"help"}, "", strings.TrimSpace(usage), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+			if code != tt.code {
+				t.Fatalf("expected exit code %d, got %d (stderr: %s)", tt.code, code, stderr.String())
+			}
+			if got := strings.TrimSpace(stdout.String()); got != tt.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunPalindromeJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"palindrome", "-format", "json", "-scan", "words"}, strings.NewReader("a kayak and a level"), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d (stderr: %s)", code, stderr.String())
+	}
+	var findings []struct {
+		File string `json:"file"`
+		Text string `json:"text"`
+		Line int    `json:"line"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(findings) != 2 || findings[0].Text != "kayak" || findings[1].Text != "level" || findings[0].File != "-" {
+		t.Errorf("unexpected findings %+v", findings)
+	}
+}
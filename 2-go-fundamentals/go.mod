@@ -1,3 +1,10 @@
 module go-fundamentals
 
 go 1.24.4
+
+require (
+	golang.org/x/term v0.40.0
+	golang.org/x/text v0.34.0
+)
+
+require golang.org/x/sys v0.41.0 // indirect
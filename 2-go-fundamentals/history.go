@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// historySize is the number of REPL commands kept in memory and on disk.
+// The file may grow to twice this many lines before it is rewritten.
+const historySize = 1000
+
+// defaultHistoryPath returns ~/.go-fundamentals_history, or "" if there is no
+// home directory, in which case history is not saved.
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".go-fundamentals_history")
+}
+
+// history is the REPL command history, oldest first. Every added entry is
+// also appended to a file so that it survives restarts. It implements
+// term.History.
+type history struct {
+	entries []string
+	path    string // empty to keep history in memory only
+	lines   int    // lines currently in the file at path
+	err     error  // first error writing to path; saving stops after it
+}
+
+// loadHistory reads the last historySize entries from path. A missing file
+// is not an error. An empty path gives an in-memory history.
+func loadHistory(path string) (*history, error) {
+	h := &history{path: path}
+	if path == "" {
+		return h, nil
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	h.lines = len(h.entries)
+	if len(h.entries) > historySize {
+		h.entries = h.entries[len(h.entries)-historySize:]
+		if err := h.rewrite(); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Add records entry unless it is blank or repeats the previous entry.
+func (h *history) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > historySize {
+		h.entries = h.entries[1:]
+	}
+	if h.path == "" || h.err != nil {
+		return
+	}
+	if h.lines >= 2*historySize {
+		h.err = h.rewrite()
+		return
+	}
+	if h.err = appendLine(h.path, entry); h.err == nil {
+		h.lines++
+	}
+}
+
+// rewrite replaces the file with the entries in memory, so that it does not
+// grow without bound.
+func (h *history) rewrite() error {
+	data := strings.Join(h.entries, "\n") + "\n"
+	if err := os.WriteFile(h.path, []byte(data), 0o600); err != nil {
+		return err
+	}
+	h.lines = len(h.entries)
+	return nil
+}
+
+// Len returns the number of entries.
+func (h *history) Len() int {
+	return len(h.entries)
+}
+
+// At returns the idx-th most recent entry, 0 being the latest.
+func (h *history) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+func appendLine(path, line string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
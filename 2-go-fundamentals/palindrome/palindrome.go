@@ -1,21 +1,206 @@
 package palindrome
 
 import (
-	"regexp"
-	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
 )
 
-// IsPalindrome checks if a string is a palindrome ignoring punctuation and case.
-func IsPalindrome(s string) bool {
-	s = strings.ToLower(s)
-	re := regexp.MustCompile(`[^a-z0-9]`)
-	clean := re.ReplaceAllString(s, "")
+type config struct {
+	keepPunctuation bool
+	keepWhitespace  bool
+	caseSensitive   bool
+	keepAccents     bool
+}
+
+// Option changes which differences IsPalindrome ignores.
+type Option func(*config)
 
-	n := len(clean)
+// KeepPunctuation makes punctuation and symbols significant.
+func KeepPunctuation() Option {
+	return func(c *config) { c.keepPunctuation = true }
+}
+
+// KeepWhitespace makes spaces and line breaks significant.
+func KeepWhitespace() Option {
+	return func(c *config) { c.keepWhitespace = true }
+}
+
+// CaseSensitive stops "A" and "a" from matching.
+func CaseSensitive() Option {
+	return func(c *config) { c.caseSensitive = true }
+}
+
+// KeepAccents stops "é" and "e" from matching. Accent folding only applies
+// to Latin and Greek letters, where marks are accents, stress marks and
+// diaereses. Elsewhere a mark can make a different letter, such as Cyrillic
+// "й" against "и" or the vowel signs of Devanagari, so it is always significant.
+func KeepAccents() Option {
+	return func(c *config) { c.keepAccents = true }
+}
+
+// IsPalindrome checks if a string is a palindrome ignoring punctuation and case.
+// Text is NFKD-normalized and compared as grapheme clusters (a base character
+// with its combining marks); Hangul syllables are compared whole. Clusters are
+// found with simple rules rather than full Unicode segmentation, so scripts
+// whose clusters join several letters, such as Devanagari conjuncts, may be
+// split more finely than a reader would. By default accents, whitespace,
+// punctuation and symbols are ignored; opts make them significant.
+func IsPalindrome(s string, opts ...Option) bool {
+	units := newConfig(opts).units(s)
+	n := len(units)
 	for i := 0; i < n/2; i++ {
-		if clean[i] != clean[n-1-i] {
+		if units[i].key != units[n-1-i].key {
 			return false
 		}
 	}
 	return true
 }
+
+// Clusters returns the normalized grapheme clusters of s that IsPalindrome compares.
+func Clusters(s string, opts ...Option) []string {
+	units := newConfig(opts).units(s)
+	keys := make([]string, len(units))
+	for i, u := range units {
+		keys[i] = u.key
+	}
+	return keys
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// unit is one normalized grapheme cluster and the byte range of s it came from.
+// A compatibility character such as "ﬁ" yields several units sharing one range.
+type unit struct {
+	key        string
+	start, end int
+}
+
+// units splits s into grapheme clusters, normalizes each one and returns the
+// clusters that are not ignored. Clusters are normalized one at a time, rather
+// than normalizing all of s first, so that each unit keeps the byte range of
+// the original text that Longest, Maximal and Count report in a Match.
+func (c *config) units(s string) []unit {
+	var units []unit
+	var folder cases.Caser
+	if !c.caseSensitive {
+		folder = cases.Fold()
+	}
+
+	for start := 0; start < len(s); {
+		end := clusterEnd(s, start)
+		original := s[start:end]
+		if !c.caseSensitive {
+			original = folder.String(original)
+		}
+		for _, key := range c.normalize(norm.NFKD.String(original)) {
+			units = append(units, unit{key: key, start: start, end: end})
+		}
+		start = end
+	}
+	return units
+}
+
+// clusterEnd returns the byte offset just past the grapheme cluster starting at start.
+func clusterEnd(s string, start int) int {
+	r, size := utf8.DecodeRuneInString(s[start:])
+	end := start + size
+	prev := r
+	for end < len(s) {
+		r, size = utf8.DecodeRuneInString(s[end:])
+		if !extendsCluster(r) && prev != zwj {
+			break
+		}
+		prev = r
+		end += size
+	}
+	return end
+}
+
+// normalize re-segments a decomposed cluster, dropping ignored characters and
+// folded accents. It usually returns one key, but compatibility decompositions
+// such as "ﬁ" -> "fi" produce several.
+func (c *config) normalize(decomposed string) []string {
+	var keys []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			key := string(current)
+			if isJamo(current[0]) {
+				// Put the syllable NFKD took apart back together.
+				key = norm.NFC.String(key)
+			}
+			keys = append(keys, key)
+			current = current[:0]
+		}
+	}
+
+	var prev rune
+	for _, r := range decomposed {
+		switch {
+		case extendsCluster(r) || prev == zwj:
+			if len(current) == 0 {
+				// A mark with no base, or one attached to an ignored character.
+				break
+			}
+			if !c.keepAccents && unicode.Is(unicode.Mn, r) && isAccented(current[0]) {
+				break
+			}
+			current = append(current, r)
+		case isTrailingJamo(r) && len(current) > 0 && isJamo(current[len(current)-1]):
+			current = append(current, r)
+		case c.dropped(r):
+			flush()
+		default:
+			flush()
+			current = append(current, r)
+		}
+		prev = r
+	}
+	flush()
+	return keys
+}
+
+const zwj = '‍'
+
+// extendsCluster reports whether r attaches to the preceding character.
+func extendsCluster(r rune) bool {
+	return unicode.IsMark(r) || r == zwj || (r >= 0x1F3FB && r <= 0x1F3FF) // emoji skin-tone modifiers
+}
+
+// isJamo reports whether r is a conjoining Hangul jamo, which is how NFKD
+// writes a Hangul syllable.
+func isJamo(r rune) bool {
+	return r >= 0x1100 && r <= 0x11FF
+}
+
+// isTrailingJamo reports whether r is a vowel or final consonant jamo, which
+// belongs to the syllable before it.
+func isTrailingJamo(r rune) bool {
+	return r >= 0x1160 && r <= 0x11FF
+}
+
+// isAccented reports whether base belongs to a script whose nonspacing marks are accents.
+func isAccented(base rune) bool {
+	return unicode.In(base, unicode.Latin, unicode.Greek)
+}
+
+func (c *config) dropped(r rune) bool {
+	switch {
+	case unicode.IsSpace(r):
+		return !c.keepWhitespace
+	case unicode.IsPunct(r) || unicode.IsSymbol(r):
+		return !c.keepPunctuation
+	case unicode.IsControl(r) || unicode.Is(unicode.Cf, r):
+		return true
+	}
+	return false
+}
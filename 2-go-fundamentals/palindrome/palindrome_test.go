@@ -0,0 +1,67 @@
+package palindrome
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     []Option
+		expected bool
+	}{
+		{"simple", "racecar", nil, true},
+		{"not a palindrome", "hello", nil, false},
+		{"empty", "", nil, true},
+		{"punctuation and case", "A man, a plan, a canal: Panama", nil, true},
+		{"digits", "12321", nil, true},
+		{"french with accents", "\u00c9sope reste ici et se repose", nil, true},
+		{"decomposed accents", "E\u0301sope reste ici et se repose", nil, true},
+		{"compatibility forms", "ﬁ if", nil, true},
+		{"full-width", "ＡｂＡ", nil, true},
+		{"russian", "А роза упала на лапу Азора", nil, true},
+		{"cyrillic short i is its own letter", "йи", nil, false},
+		{"cyrillic short i", "Ой, йо!", nil, true},
+		{"greek", "Νίψον ανομήματα μη μόναν όψιν", nil, true},
+		{"amharic", "ሰላሰ", nil, true},
+		{"devanagari clusters", "कीककी", nil, true},
+		{"devanagari vowel signs matter", "कीकक", nil, false},
+		{"korean", "기러기", nil, true},
+		{"korean sentence", "다시 합창합시다", nil, true},
+		{"korean syllables are whole", "가나가", nil, true},
+		{"korean final consonants matter", "각가", nil, false},
+		{"case folding", "Straße ssartS", nil, true},
+		{"case sensitive", "Aba", []Option{CaseSensitive()}, false},
+		{"keep accents", "\u00c9sope reste ici et se repose", []Option{KeepAccents()}, false},
+		{"keep whitespace", "nurses run", []Option{KeepWhitespace()}, false},
+		{"keep whitespace symmetric", "ab ba", []Option{KeepWhitespace()}, true},
+		{"keep punctuation", "a,ba", []Option{KeepPunctuation()}, false},
+		{"emoji clusters", "👍🏽x👍🏽", []Option{KeepPunctuation()}, true},
+		{"emoji ignored as symbols", "👍🏽x👍🏻", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsPalindrome(tt.input, tt.opts...); result != tt.expected {
+				t.Errorf("IsPalindrome(%q): expected %v, got %v", tt.input, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestClusters(t *testing.T) {
+	expected := []string{"e", "s", "o", "p", "e"}
+	if got := Clusters("\u00c9sope!"); !slices.Equal(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	expected = []string{"합", "창"}
+	if got := Clusters("합창"); !slices.Equal(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	expected = []string{"e\u0301", "t", "e\u0301"}
+	if got := Clusters("\u00e9t\u00e9", KeepAccents()); !slices.Equal(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
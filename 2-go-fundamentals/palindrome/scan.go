@@ -0,0 +1,211 @@
+package palindrome
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ScanMode selects what a Scanner checks for palindromes.
+type ScanMode int
+
+const (
+	// ScanWords checks every whitespace-separated word, ignoring punctuation
+	// at its edges.
+	ScanWords ScanMode = iota
+	// ScanLines checks every line.
+	ScanLines
+	// ScanSentences checks every sentence. Sentences end at sentence-terminal
+	// punctuation in any script (".", "?", "።", "。", ...) or at a blank line,
+	// and may span several lines.
+	ScanSentences
+)
+
+// Finding is a palindrome found by a Scanner.
+type Finding struct {
+	Text   string `json:"text"`
+	Line   int    `json:"line"`   // 1-based line where Text starts
+	Column int    `json:"column"` // 1-based column, in runes, where Text starts
+	Offset int64  `json:"offset"` // byte offset of Text in the stream
+}
+
+// Scanner reads text from an io.Reader one line at a time and reports the
+// palindromic words, lines or sentences in it. Use it like bufio.Scanner:
+//
+//	s := palindrome.NewScanner(r, palindrome.ScanWords)
+//	for s.Scan() {
+//		fmt.Println(s.Finding())
+//	}
+//	if err := s.Err(); err != nil { ... }
+type Scanner struct {
+	// MinLength is the minimum number of significant clusters a palindrome
+	// must have to be reported. It defaults to 2, which skips single letters
+	// and blank lines.
+	MinLength int
+
+	r       *bufio.Reader
+	mode    ScanMode
+	cfg     *config
+	pending []Finding
+	finding Finding
+	err     error
+	done    bool
+
+	line   int
+	offset int64
+
+	sentence      strings.Builder
+	sentenceStart Finding
+}
+
+// NewScanner returns a Scanner reading from r. opts control how text is
+// compared, exactly as for IsPalindrome.
+func NewScanner(r io.Reader, mode ScanMode, opts ...Option) *Scanner {
+	return &Scanner{
+		MinLength: 2,
+		r:         bufio.NewReader(r),
+		mode:      mode,
+		cfg:       newConfig(opts),
+	}
+}
+
+// Scan advances to the next palindrome, returning false at the end of the
+// input or on a read error.
+func (s *Scanner) Scan() bool {
+	for len(s.pending) == 0 {
+		if s.done {
+			return false
+		}
+		s.readLine()
+	}
+	s.finding = s.pending[0]
+	s.pending = s.pending[1:]
+	return true
+}
+
+// Finding returns the palindrome found by the last call to Scan.
+func (s *Scanner) Finding() Finding {
+	return s.finding
+}
+
+// Err returns the first non-EOF error encountered while reading.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// ScanAll collects every finding in r.
+func ScanAll(r io.Reader, mode ScanMode, opts ...Option) ([]Finding, error) {
+	s := NewScanner(r, mode, opts...)
+	var findings []Finding
+	for s.Scan() {
+		findings = append(findings, s.Finding())
+	}
+	return findings, s.Err()
+}
+
+func (s *Scanner) readLine() {
+	text, err := s.r.ReadString('\n')
+	if len(text) > 0 {
+		s.line++
+		switch s.mode {
+		case ScanWords:
+			s.scanWords(text)
+		case ScanLines:
+			s.check(Finding{Text: strings.TrimRight(text, "\r\n"), Line: s.line, Column: 1, Offset: s.offset})
+		case ScanSentences:
+			s.scanSentences(text)
+		}
+		s.offset += int64(len(text))
+	}
+	if err != nil {
+		if err != io.EOF {
+			s.err = err
+		}
+		s.endSentence()
+		s.done = true
+	}
+}
+
+func (s *Scanner) scanWords(text string) {
+	column := 1
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if unicode.IsSpace(r) {
+			i += size
+			column++
+			continue
+		}
+
+		end := i
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if unicode.IsSpace(r) {
+				break
+			}
+			end += size
+		}
+
+		word := text[i:end]
+		trimmed := strings.TrimLeftFunc(word, unicode.IsPunct)
+		lead := len(word) - len(trimmed)
+		trimmed = strings.TrimRightFunc(trimmed, unicode.IsPunct)
+		s.check(Finding{
+			Text:   trimmed,
+			Line:   s.line,
+			Column: column + utf8.RuneCountInString(word[:lead]),
+			Offset: s.offset + int64(i+lead),
+		})
+
+		column += utf8.RuneCountInString(word)
+		i = end
+	}
+}
+
+func (s *Scanner) scanSentences(text string) {
+	if strings.TrimSpace(text) == "" {
+		s.endSentence()
+		return
+	}
+
+	column := 1
+	for i, r := range text {
+		if s.sentence.Len() == 0 {
+			if unicode.IsSpace(r) {
+				column++
+				continue
+			}
+			s.sentenceStart = Finding{Line: s.line, Column: column, Offset: s.offset + int64(i)}
+		}
+		s.sentence.WriteRune(r)
+		if unicode.Is(unicode.Sentence_Terminal, r) {
+			s.endSentence()
+		}
+		column++
+	}
+}
+
+func (s *Scanner) endSentence() {
+	if s.sentence.Len() == 0 {
+		return
+	}
+	f := s.sentenceStart
+	f.Text = strings.TrimSpace(s.sentence.String())
+	s.sentence.Reset()
+	s.check(f)
+}
+
+// check queues f if its text is a long enough palindrome.
+func (s *Scanner) check(f Finding) {
+	units := s.cfg.units(f.Text)
+	if len(units) < s.MinLength {
+		return
+	}
+	for i, n := 0, len(units); i < n/2; i++ {
+		if units[i].key != units[n-1-i].key {
+			return
+		}
+	}
+	s.pending = append(s.pending, f)
+}
@@ -0,0 +1,93 @@
+package palindrome
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestScanWords(t *testing.T) {
+	input := "Anna saw a kayak,\n  then \"Level\" racecars; ሰላሰ!\n기러기 보다\n"
+	findings, err := ScanAll(strings.NewReader(input), ScanWords)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Finding{
+		{Text: "Anna", Line: 1, Column: 1, Offset: 0},
+		{Text: "kayak", Line: 1, Column: 12, Offset: 11},
+		{Text: "Level", Line: 2, Column: 9, Offset: 26},
+		{Text: "ሰላሰ", Line: 2, Column: 26, Offset: 43},
+		{Text: "기러기", Line: 3, Column: 1, Offset: 54},
+	}
+	if !slices.Equal(findings, expected) {
+		t.Errorf("expected %+v, got %+v", expected, findings)
+	}
+	for _, f := range findings {
+		if got := input[f.Offset : f.Offset+int64(len(f.Text))]; got != f.Text {
+			t.Errorf("offset %d points at %q, not %q", f.Offset, got, f.Text)
+		}
+	}
+}
+
+func TestScanLines(t *testing.T) {
+	input := "Was it a car or a cat I saw?\r\nnot this one\n\nNo lemon, no melon"
+	findings, err := ScanAll(strings.NewReader(input), ScanLines)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Finding{
+		{Text: "Was it a car or a cat I saw?", Line: 1, Column: 1, Offset: 0},
+		{Text: "No lemon, no melon", Line: 4, Column: 1, Offset: 44},
+	}
+	if !slices.Equal(findings, expected) {
+		t.Errorf("expected %+v, got %+v", expected, findings)
+	}
+}
+
+func TestScanSentences(t *testing.T) {
+	input := "He said hello. A man, a plan,\na canal: Panama! Nope.\n\nStep on no pets\n\nNever odd or even"
+	findings, err := ScanAll(strings.NewReader(input), ScanSentences)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Finding{
+		{Text: "A man, a plan,\na canal: Panama!", Line: 1, Column: 16, Offset: 15},
+		{Text: "Step on no pets", Line: 4, Column: 1, Offset: 54},
+		{Text: "Never odd or even", Line: 6, Column: 1, Offset: 71},
+	}
+	if !slices.Equal(findings, expected) {
+		t.Errorf("expected %+v, got %+v", expected, findings)
+	}
+}
+
+func TestScannerMinLength(t *testing.T) {
+	s := NewScanner(strings.NewReader("a bob noon I level"), ScanWords)
+	s.MinLength = 4
+	var words []string
+	for s.Scan() {
+		words = append(words, s.Finding().Text)
+	}
+	if expected := []string{"noon", "level"}; !slices.Equal(words, expected) {
+		t.Errorf("expected %v, got %v", expected, words)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func TestScannerError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("noon\n"), failingReader{})
+	findings, err := ScanAll(r, ScanWords)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected read error, got %v", err)
+	}
+	if len(findings) != 1 {
+		t.Errorf("expected findings before the error, got %v", findings)
+	}
+}
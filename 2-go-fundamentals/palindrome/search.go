@@ -0,0 +1,121 @@
+package palindrome
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Match is a palindromic substring of the searched text.
+type Match struct {
+	Text  string `json:"text"`  // the substring as it appears in the input
+	Start int    `json:"start"` // byte offset of Text in the input
+	End   int    `json:"end"`   // byte offset just past Text
+	// Length is the number of normalized clusters in the palindrome,
+	// which ignores punctuation, whitespace and so on per the options.
+	Length int `json:"length"`
+}
+
+// manacher holds palindrome radii for every center of a unit sequence.
+// odd[i] is the number of odd-length palindromes centered on unit i, and
+// even[i] the number of even-length ones centered between units i-1 and i.
+type manacher struct {
+	units []unit
+	odd   []int
+	even  []int
+}
+
+// newManacher runs Manacher's algorithm over the normalized units of s in O(n).
+func newManacher(s string, opts []Option) *manacher {
+	units := newConfig(opts).units(s)
+	n := len(units)
+	m := &manacher{units: units, odd: make([]int, n), even: make([]int, n)}
+
+	for i, l, r := 0, 0, -1; i < n; i++ {
+		k := 1
+		if i <= r {
+			k = min(m.odd[l+r-i], r-i+1)
+		}
+		for i-k >= 0 && i+k < n && units[i-k].key == units[i+k].key {
+			k++
+		}
+		m.odd[i] = k
+		if i+k-1 > r {
+			l, r = i-k+1, i+k-1
+		}
+	}
+
+	for i, l, r := 0, 0, -1; i < n; i++ {
+		k := 0
+		if i <= r {
+			k = min(m.even[l+r-i+1], r-i+1)
+		}
+		for i-k-1 >= 0 && i+k < n && units[i-k-1].key == units[i+k].key {
+			k++
+		}
+		m.even[i] = k
+		if i+k-1 > r {
+			l, r = i-k, i+k-1
+		}
+	}
+	return m
+}
+
+// match converts the unit range [first, last] into a Match on s.
+func (m *manacher) match(s string, first, last int) Match {
+	start, end := m.units[first].start, m.units[last].end
+	return Match{Text: s[start:end], Start: start, End: end, Length: last - first + 1}
+}
+
+// Longest returns the longest palindromic substring of s, comparing text the
+// same way IsPalindrome does. When several are equally long the first wins.
+// The zero Match is returned if s has no significant characters.
+func Longest(s string, opts ...Option) Match {
+	m := newManacher(s, opts)
+	best, first, last := 0, 0, -1
+	for i := range m.units {
+		if l := 2*m.odd[i] - 1; l > best {
+			best, first, last = l, i-m.odd[i]+1, i+m.odd[i]-1
+		}
+		if l := 2 * m.even[i]; l > best {
+			best, first, last = l, i-m.even[i], i+m.even[i]-1
+		}
+	}
+	if last < 0 {
+		return Match{}
+	}
+	return m.match(s, first, last)
+}
+
+// Maximal returns every maximal palindromic substring of s of at least
+// minLength clusters: for each center, the palindrome that cannot be extended
+// further. Results are ordered by position, then by length.
+func Maximal(s string, minLength int, opts ...Option) []Match {
+	m := newManacher(s, opts)
+	var matches []Match
+	for i := range m.units {
+		if k := m.odd[i]; 2*k-1 >= minLength {
+			matches = append(matches, m.match(s, i-k+1, i+k-1))
+		}
+		if k := m.even[i]; k > 0 && 2*k >= minLength {
+			matches = append(matches, m.match(s, i-k, i+k-1))
+		}
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		if c := cmp.Compare(a.Start, b.Start); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Length, b.Length)
+	})
+	return matches
+}
+
+// Count returns the number of palindromic substrings of s, counting each
+// distinct position separately and including single clusters, in linear time.
+func Count(s string, opts ...Option) int {
+	m := newManacher(s, opts)
+	total := 0
+	for i := range m.units {
+		total += m.odd[i] + m.even[i]
+	}
+	return total
+}
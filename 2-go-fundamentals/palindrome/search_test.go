@@ -0,0 +1,87 @@
+package palindrome
+
+import (
+	"testing"
+)
+
+// bruteCount counts palindromic substrings by checking every range of clusters.
+func bruteCount(s string) int {
+	keys := Clusters(s)
+	total := 0
+	for i := range keys {
+		for j := i; j < len(keys); j++ {
+			ok := true
+			for a, b := i, j; a < b; a, b = a+1, b-1 {
+				if keys[a] != keys[b] {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				total++
+			}
+		}
+	}
+	return total
+}
+
+func TestLongest(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"odd", "babad", "bab"},
+		{"even", "cbbd", "bb"},
+		{"whole", "racecar", "racecar"},
+		{"inside sentence", "She said: Was it a car or a cat I saw? Then left.", "Was it a car or a cat I saw"},
+		{"accents", "yy xx Été xx", "xx Été xx"},
+		{"amharic", "ሀሰላሰለ", "ሰላሰ"},
+		{"korean", "xx기러기yy", "기러기"},
+		{"empty", "", ""},
+		{"only punctuation", "?!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Longest(tt.input)
+			if m.Text != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, m.Text)
+			}
+			if tt.input[m.Start:m.End] != m.Text {
+				t.Errorf("offsets %d:%d do not match text %q", m.Start, m.End, m.Text)
+			}
+		})
+	}
+}
+
+func TestMaximal(t *testing.T) {
+	matches := Maximal("abacdedc", 3)
+	expected := []Match{
+		{Text: "aba", Start: 0, End: 3, Length: 3},
+		{Text: "cdedc", Start: 3, End: 8, Length: 5},
+	}
+	if len(matches) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, matches)
+	}
+	for i := range expected {
+		if matches[i] != expected[i] {
+			t.Errorf("match %d: expected %+v, got %+v", i, expected[i], matches[i])
+		}
+	}
+
+	if all := Maximal("aaa", 1); len(all) != 5 {
+		t.Errorf("expected one maximal palindrome per center (5), got %v", all)
+	}
+}
+
+func TestCount(t *testing.T) {
+	for _, s := range []string{"", "a", "abc", "aaa", "abba", "abacaba", "A man, a plan, a canal: Panama", "ﬁ if"} {
+		if got, want := Count(s), bruteCount(s); got != want {
+			t.Errorf("Count(%q): expected %d, got %d", s, want, got)
+		}
+	}
+	if got := Count("aaa"); got != 6 {
+		t.Errorf("expected 6, got %d", got)
+	}
+}
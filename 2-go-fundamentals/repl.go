@@ -0,0 +1,378 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go-fundamentals/anagram"
+	"go-fundamentals/palindrome"
+	"go-fundamentals/textstats"
+	"go-fundamentals/wordfreq"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const prompt = "> "
+
+// command describes a REPL command for help and tab completion.
+type command struct {
+	name     string
+	synopsis string
+	help     string
+}
+
+var commands = []command{
+	{"add", "add <text>", "append text to the session and update the word counts"},
+	{"top", "top [n]", "show the n most frequent words so far (default 10)"},
+	{"count", "count <word>", "show how often word occurred"},
+	{"stats", "stats", "show session totals and per-language statistics"},
+	{"palindrome", "palindrome [text]", "check text, or else the last added text, for being a palindrome"},
+	{"longest", "longest", "show the longest palindrome in the session text"},
+	{"suggest", "suggest <word>", "suggest words from the session that are close to word"},
+	{"format", "format [name]", "show or set the output format: table, csv, json or markdown"},
+	{"sort", "sort [order]", "show or set how top lists words: count or word"},
+	{"reset", "reset", "forget the session text and counts, keeping the format and sort order"},
+	{"history", "history", "list previous commands"},
+	{"help", "help", "list the commands"},
+	{"quit", "quit", "leave the REPL (also exit, Ctrl-D)"},
+}
+
+// lineReader reads one line of input without its line terminator.
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+// runREPL runs the interactive shell. On a terminal it offers line editing,
+// history and tab completion; otherwise it reads commands line by line, so
+// scripts can be piped in. It returns when the input ends or on "quit".
+// History is only saved by default on a terminal, so piped scripts do not
+// fill the user's history file.
+func runREPL(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := newFlagSet("repl", "repl [flags]", stderr)
+	defaultHistory := ""
+	if isTerminal(stdin) {
+		defaultHistory = defaultHistoryPath()
+	}
+	historyPath := fs.String("history", defaultHistory, "save command history to `file` (empty to disable)")
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	hist, err := loadHistory(*historyPath)
+	if err != nil {
+		fmt.Fprintln(stderr, "go-fundamentals:", err)
+		return exitError
+	}
+
+	var in lineReader
+	out := stdout
+	restore := func() {}
+	if t, restoreTerminal, ok := openTerminal(stdin, stdout, hist); ok {
+		defer restoreTerminal()
+		in, out, restore = t, t, restoreTerminal
+		fmt.Fprintln(out, "Go Fundamentals REPL. Type help for commands, Tab to complete, Ctrl-D to quit.")
+	} else {
+		in = &plainReader{r: bufio.NewReader(stdin)}
+	}
+
+	s := newSession(out, hist)
+	for {
+		line, err := in.ReadLine()
+		if err != nil && !errors.Is(err, term.ErrPasteIndicator) {
+			if errors.Is(err, io.EOF) {
+				return exitOK
+			}
+			restore() // leave raw mode so the message gets proper line endings
+			fmt.Fprintln(stderr, "go-fundamentals:", err)
+			return exitError
+		}
+		if _, isTerminal := in.(*term.Terminal); !isTerminal {
+			hist.Add(line) // the terminal records its own history
+		}
+		if hist.err != nil {
+			fmt.Fprintln(out, "⚠️ history not saved:", hist.err)
+			hist.path, hist.err = "", nil
+		}
+		if s.exec(line) {
+			return exitOK
+		}
+	}
+}
+
+// openTerminal puts stdin in raw mode and returns a line editor on it, if
+// both stdin and stdout are terminals. restore undoes the raw mode.
+func openTerminal(stdin io.Reader, stdout io.Writer, hist *history) (t *term.Terminal, restore func(), ok bool) {
+	if !isTerminal(stdin) || !isTerminal(stdout) {
+		return nil, nil, false
+	}
+	in, out := stdin.(*os.File), stdout.(*os.File)
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	t = term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, prompt)
+	t.History = hist
+	t.AutoCompleteCallback = complete
+	if width, height, err := term.GetSize(int(out.Fd())); err == nil && width > 0 {
+		t.SetSize(width, height)
+	}
+	return t, func() { term.Restore(int(in.Fd()), state) }, true
+}
+
+// isTerminal reports whether f is a file connected to a terminal.
+func isTerminal(f any) bool {
+	file, ok := f.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// plainReader reads lines from a non-terminal input. A last line without a
+// newline is still returned before io.EOF.
+type plainReader struct {
+	r *bufio.Reader
+}
+
+func (p *plainReader) ReadLine() (string, error) {
+	line, err := p.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+// complete is the terminal's AutoCompleteCallback. Tab completes the command
+// name being typed, or an argument after "format " or "sort ". With several matches
+// it completes their common prefix.
+func complete(line string, pos int, key rune) (newLine string, newPos int, ok bool) {
+	if key != '\t' {
+		return "", 0, false
+	}
+	before := line[:pos]
+	word := before
+	var candidates []string
+	if name, arg, found := strings.Cut(before, " "); !found {
+		for _, c := range commands {
+			candidates = append(candidates, c.name)
+		}
+	} else if name == "format" && !strings.Contains(arg, " ") {
+		candidates, word = wordfreq.FormatNames(), arg
+	} else if name == "sort" && !strings.Contains(arg, " ") {
+		candidates, word = wordfreq.SortOrderNames(), arg
+	} else {
+		return "", 0, false
+	}
+
+	var matches []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+	completion := matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, completion) {
+			completion = completion[:len(completion)-1]
+		}
+	}
+	if len(matches) == 1 && word == before {
+		completion += " "
+	}
+	if completion == word {
+		return "", 0, false
+	}
+
+	newBefore := before[:len(before)-len(word)] + completion
+	return newBefore + line[pos:], len(newBefore), true
+}
+
+// session is the state kept between REPL commands: all text added so far and
+// its running word counts.
+type session struct {
+	out     io.Writer
+	hist    *history
+	text    strings.Builder
+	last    string
+	inputs  int
+	counter *wordfreq.Counter
+
+	format     wordfreq.Format
+	formatName string
+	order      wordfreq.SortOrder
+	orderName  string
+}
+
+func newSession(out io.Writer, hist *history) *session {
+	return &session{
+		out:        out,
+		hist:       hist,
+		counter:    wordfreq.NewCounter(),
+		format:     wordfreq.FormatTable,
+		formatName: "table",
+		order:      wordfreq.ByCount,
+		orderName:  "count",
+	}
+}
+
+// exec runs one command line and reports whether the REPL should stop.
+func (s *session) exec(line string) (quit bool) {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch name {
+	case "":
+	case "add":
+		s.add(arg)
+	case "top":
+		n := 10
+		if arg != "" {
+			var err error
+			if n, err = strconv.Atoi(arg); err != nil || n < 1 {
+				fmt.Fprintf(s.out, "⚠️ invalid count %q\n", arg)
+				return false
+			}
+		}
+		counts := s.counter.TopK(n)
+		wordfreq.SortCounts(counts, s.order)
+		s.writeCounts(counts)
+	case "count":
+		if arg == "" {
+			fmt.Fprintln(s.out, "⚠️ usage: count <word>")
+			return false
+		}
+		word := strings.ToLower(arg)
+		fmt.Fprintf(s.out, "%s: %d\n", word, s.counter.Count(word))
+	case "stats":
+		s.stats()
+	case "palindrome":
+		text := arg
+		if text == "" {
+			text = s.last
+		}
+		if text == "" {
+			fmt.Fprintln(s.out, "⚠️ usage: palindrome <text>, or add some text first")
+		} else if palindrome.IsPalindrome(text) {
+			fmt.Fprintln(s.out, "✅ It's a palindrome!")
+		} else {
+			fmt.Fprintln(s.out, "❌ Not a palindrome.")
+		}
+	case "longest":
+		if m := palindrome.Longest(s.text.String()); m.Length > 0 {
+			fmt.Fprintf(s.out, "%q (%d characters)\n", m.Text, m.Length)
+		} else {
+			fmt.Fprintln(s.out, "no palindromes yet")
+		}
+	case "suggest":
+		s.suggest(arg)
+	case "format":
+		if arg == "" {
+			fmt.Fprintln(s.out, "format:", s.formatName)
+			return false
+		}
+		f, err := wordfreq.ParseFormat(arg)
+		if err != nil {
+			fmt.Fprintln(s.out, "⚠️", err)
+			return false
+		}
+		s.format, s.formatName = f, strings.ToLower(arg)
+	case "sort":
+		if arg == "" {
+			fmt.Fprintln(s.out, "sort:", s.orderName)
+			return false
+		}
+		order, err := wordfreq.ParseSortOrder(arg)
+		if err != nil {
+			fmt.Fprintln(s.out, "⚠️", err)
+			return false
+		}
+		s.order, s.orderName = order, strings.ToLower(arg)
+	case "reset":
+		s.text.Reset()
+		s.last, s.inputs = "", 0
+		s.counter = wordfreq.NewCounter()
+		fmt.Fprintln(s.out, "session cleared")
+	case "history":
+		for i := s.hist.Len() - 1; i >= 0; i-- {
+			fmt.Fprintf(s.out, "%4d  %s\n", s.hist.Len()-i, s.hist.At(i))
+		}
+	case "help":
+		for _, c := range commands {
+			fmt.Fprintf(s.out, "  %-18s %s\n", c.synopsis, c.help)
+		}
+	case "quit", "exit":
+		return true
+	default:
+		s.unknown(name)
+	}
+	return false
+}
+
+func (s *session) add(text string) {
+	if text == "" {
+		fmt.Fprintln(s.out, "⚠️ usage: add <text>")
+		return
+	}
+	before := s.counter.Total()
+	s.counter.AddText(text)
+	s.text.WriteString(text)
+	s.text.WriteByte('\n')
+	s.last = text
+	s.inputs++
+	fmt.Fprintf(s.out, "added %d words (%d total, %d distinct)\n",
+		s.counter.Total()-before, s.counter.Total(), s.counter.Len())
+}
+
+func (s *session) writeCounts(counts []wordfreq.WordCount) {
+	if err := wordfreq.WriteCounts(s.out, counts, s.format); err != nil {
+		fmt.Fprintln(s.out, "⚠️", err)
+	}
+}
+
+func (s *session) stats() {
+	fmt.Fprintf(s.out, "inputs: %d, words: %d, distinct: %d\n", s.inputs, s.counter.Total(), s.counter.Len())
+	for _, st := range textstats.Analyze(s.text.String()) {
+		fmt.Fprintf(s.out, "  %s: %d sentences, %d words, %.1f letters/word, LIX %.1f",
+			st.Language, st.Sentences, st.Words, st.AverageWordLength, st.LIX)
+		if st.FleschReadingEase != nil {
+			fmt.Fprintf(s.out, ", Flesch %.1f", *st.FleschReadingEase)
+		}
+		fmt.Fprintln(s.out)
+	}
+}
+
+func (s *session) suggest(word string) {
+	if word == "" {
+		fmt.Fprintln(s.out, "⚠️ usage: suggest <word>")
+		return
+	}
+	suggestions := anagram.NewSuggester(s.counter.Counts()).Suggest(word, 5)
+	if len(suggestions) == 0 {
+		fmt.Fprintln(s.out, "no suggestions")
+		return
+	}
+	for _, sg := range suggestions {
+		fmt.Fprintf(s.out, "%s (distance %d, seen %d)\n", sg.Word, sg.Distance, sg.Count)
+	}
+}
+
+// unknown reports an unknown command, suggesting the closest real one.
+func (s *session) unknown(name string) {
+	names := make(map[string]int, len(commands))
+	for _, c := range commands {
+		names[c.name] = 1
+	}
+	if guess, ok := anagram.NewSuggester(names).DidYouMean(name); ok {
+		fmt.Fprintf(s.out, "⚠️ unknown command %q, did you mean %q?\n", name, guess)
+		return
+	}
+	fmt.Fprintf(s.out, "⚠️ unknown command %q (type help for a list)\n", name)
+}
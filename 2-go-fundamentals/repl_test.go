@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestREPL(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history")
+	script := strings.Join([]string{
+		"add the cat and the hat",
+		"add The cat sat",
+		"count cat",
+		"format csv",
+		"top 2",
+		"sort word",
+		"top 2",
+		"sort",
+		"sort length",
+		"palindrome",
+		"add Step on no pets",
+		"palindrome",
+		"longest",
+		"suggest hta",
+		"stats",
+		"tpo",
+		"reset",
+		"count cat",
+		"quit",
+		"add never reached",
+	}, "\n")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"repl", "-history", historyFile}, strings.NewReader(script), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("expected exit code %d, got %d (stderr %q)", exitOK, code, stderr.String())
+	}
+
+	out := stdout.String()
+	for _, expected := range []string{
+		"added 5 words (5 total, 4 distinct)",
+		"added 3 words (8 total, 5 distinct)",
+		"cat: 2\n",
+		"word,count\nthe,3\ncat,2\n",
+		"word,count\ncat,2\nthe,3\n",
+		"sort: word\n",
+		`unknown sort order "length"`,
+		"❌ Not a palindrome.",
+		"✅ It's a palindrome!",
+		`"Step on no pets" (12 characters)`,
+		"hat (distance 1, seen 1)",
+		"inputs: 3, words: 12, distinct: 9",
+		`unknown command "tpo", did you mean "top"?`,
+		"session cleared\ncat: 0\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "never reached") {
+		t.Error("expected input after quit to be ignored")
+	}
+
+	data, err := os.ReadFile(historyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 19 || lines[18] != "quit" {
+		t.Errorf("expected the 19 commands up to quit in the history file, got %q", lines)
+	}
+}
+
+func TestREPLEndOfInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		stdin string
+	}{
+		{"empty", ""},
+		{"no trailing newline", "add a b c"},
+		{"only newlines", "\n\n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run([]string{"repl", "-history", ""}, strings.NewReader(tt.stdin), &stdout, &stderr); code != exitOK {
+				t.Errorf("expected exit code %d, got %d", exitOK, code)
+			}
+		})
+	}
+
+	var stdout bytes.Buffer
+	run([]string{"repl", "-history", ""}, strings.NewReader("add a b c"), &stdout, &bytes.Buffer{})
+	if !strings.Contains(stdout.String(), "added 3 words") {
+		t.Errorf("expected the last line to be processed, got %q", stdout.String())
+	}
+}
+
+func TestREPLHistoryAcrossRuns(t *testing.T) {
+	historyFile := filepath.Join(t.TempDir(), "history")
+	run([]string{"repl", "-history", historyFile}, strings.NewReader("add one\nadd one\n\nadd two\n"), &bytes.Buffer{}, &bytes.Buffer{})
+
+	var stdout bytes.Buffer
+	run([]string{"repl", "-history", historyFile}, strings.NewReader("history\n"), &stdout, &bytes.Buffer{})
+	expected := "   1  add one\n   2  add two\n   3  history\n"
+	if stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestREPLPipedInputSkipsDefaultHistory(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if code := run([]string{"repl"}, strings.NewReader("add one\n"), &bytes.Buffer{}, &bytes.Buffer{}); code != exitOK {
+		t.Fatalf("expected exit code %d, got %d", exitOK, code)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".go-fundamentals_history")); !os.IsNotExist(err) {
+		t.Errorf("expected no history file for piped input, got %v", err)
+	}
+}
+
+func TestLoadHistoryTrims(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	var sb strings.Builder
+	for i := range historySize + 5 {
+		fmt.Fprintf(&sb, "cmd %d\n", i)
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := loadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Len() != historySize || h.At(0) != fmt.Sprintf("cmd %d", historySize+4) || h.At(historySize-1) != "cmd 5" {
+		t.Errorf("expected the last %d entries, got %d from %q to %q", historySize, h.Len(), h.At(historySize-1), h.At(0))
+	}
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "\n"); n != historySize {
+		t.Errorf("expected the file to be trimmed to %d lines, got %d", historySize, n)
+	}
+}
+
+func TestHistoryTrimsWhileAdding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h, err := loadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := 3*historySize + 7
+	for i := range last + 1 {
+		h.Add(fmt.Sprintf("cmd %d", i))
+	}
+	if h.err != nil {
+		t.Fatal(h.err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) < historySize || len(lines) > 2*historySize {
+		t.Errorf("expected between %d and %d lines in the file, got %d", historySize, 2*historySize, len(lines))
+	}
+	if expected := fmt.Sprintf("cmd %d", last); lines[len(lines)-1] != expected {
+		t.Errorf("expected the file to end with %q, got %q", expected, lines[len(lines)-1])
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		pos     int
+		key     rune
+		newLine string
+		newPos  int
+		ok      bool
+	}{
+		{"unique command", "pal", 3, '\t', "palindrome ", 11, true},
+		{"ambiguous", "h", 1, '\t', "", 0, false},
+		{"unique after two letters", "st", 2, '\t', "stats ", 6, true},
+		{"single letter", "c", 1, '\t', "count ", 6, true},
+		{"format argument", "format j", 8, '\t', "format json", 11, true},
+		{"sort argument", "sort w", 6, '\t', "sort word", 9, true},
+		{"cursor inside line", "toxyz", 2, '\t', "top xyz", 4, true},
+		{"no match", "zzz", 3, '\t', "", 0, false},
+		{"other argument", "add pal", 7, '\t', "", 0, false},
+		{"not tab", "pal", 3, 'x', "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newLine, newPos, ok := complete(tt.line, tt.pos, tt.key)
+			if !tt.ok {
+				if ok {
+					t.Errorf("expected no completion, got %q at %d", newLine, newPos)
+				}
+				return
+			}
+			if !ok || newLine != tt.newLine || newPos != tt.newPos {
+				t.Errorf("expected %q at %d, got %q at %d (ok %v)", tt.newLine, tt.newPos, newLine, newPos, ok)
+			}
+		})
+	}
+}
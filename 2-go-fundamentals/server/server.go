@@ -0,0 +1,364 @@
+// Package server exposes the wordfreq and palindrome analyses over HTTP.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-fundamentals/palindrome"
+	"go-fundamentals/wordfreq"
+)
+
+// Config limits what a single request may send.
+type Config struct {
+	// MaxBodyBytes caps JSON request bodies. Default 1 MiB.
+	MaxBodyBytes int64
+	// MaxUploadBytes caps text/plain uploads, which are processed as a
+	// stream rather than held in memory. Default 64 MiB.
+	MaxUploadBytes int64
+	// MaxBatchItems caps the number of requests in one POST /batch. Default 100.
+	MaxBatchItems int
+	// MaxFindings caps the findings returned by one palindrome scan; the
+	// response is marked truncated when the cap is hit. Default 10000.
+	MaxFindings int
+	// ErrorLog receives errors the client cannot be told about, such as a
+	// failed response write. If nil, the log package's standard logger is used.
+	ErrorLog *log.Logger
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxBodyBytes <= 0 {
+		c.MaxBodyBytes = 1 << 20
+	}
+	if c.MaxUploadBytes <= 0 {
+		c.MaxUploadBytes = 64 << 20
+	}
+	if c.MaxBatchItems <= 0 {
+		c.MaxBatchItems = 100
+	}
+	if c.MaxFindings <= 0 {
+		c.MaxFindings = 10000
+	}
+	return c
+}
+
+// New returns the HTTP handler serving:
+//
+//	POST /wordfreq     word counts for JSON {"text": ...} or a text/plain upload
+//	POST /palindrome   palindrome check for JSON {"text": ...}, or a scan of a text/plain upload
+//	POST /batch        several of the above in one JSON request
+func New(cfg Config) http.Handler {
+	s := &server{cfg: cfg.withDefaults()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /wordfreq", s.wordFreq)
+	mux.HandleFunc("POST /palindrome", s.palindrome)
+	mux.HandleFunc("POST /batch", s.batch)
+	return mux
+}
+
+type server struct {
+	cfg Config
+}
+
+// WordFreqRequest is the JSON body of POST /wordfreq. For text/plain uploads
+// the same fields are read from query parameters (stop_words is then a
+// comma-separated list or "english").
+type WordFreqRequest struct {
+	Text      string   `json:"text"`
+	Top       int      `json:"top,omitempty"`
+	Sort      string   `json:"sort,omitempty"` // "count" (default) or "word"
+	StopWords []string `json:"stop_words,omitempty"`
+	English   bool     `json:"english_stop_words,omitempty"`
+	Stem      bool     `json:"stem,omitempty"`
+	MinLength int      `json:"min_length,omitempty"`
+}
+
+// WordFreqResponse is returned by POST /wordfreq.
+type WordFreqResponse struct {
+	Total int                  `json:"total"`
+	Words []wordfreq.WordCount `json:"words"`
+}
+
+// PalindromeRequest is the JSON body of POST /palindrome.
+type PalindromeRequest struct {
+	Text    string `json:"text"`
+	Longest bool   `json:"longest,omitempty"`
+}
+
+// PalindromeResponse is returned by POST /palindrome for JSON requests.
+type PalindromeResponse struct {
+	Palindrome bool              `json:"palindrome"`
+	Longest    *palindrome.Match `json:"longest,omitempty"`
+}
+
+// ScanResponse is returned by POST /palindrome?scan=words|lines|sentences for text/plain uploads.
+type ScanResponse struct {
+	Findings []palindrome.Finding `json:"findings"`
+	// Truncated is set when the scan stopped at Config.MaxFindings.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// BatchItem is one entry of a POST /batch request. Op is "wordfreq" or
+// "palindrome" and the remaining fields are those of the matching request.
+type BatchItem struct {
+	Op string `json:"op"`
+	WordFreqRequest
+	Longest bool `json:"longest,omitempty"`
+}
+
+// BatchResult is one entry of a POST /batch response, in request order.
+type BatchResult struct {
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// wordFreq handles POST /wordfreq.
+func (s *server) wordFreq(w http.ResponseWriter, r *http.Request) {
+	if isPlainText(r) {
+		req, err := wordFreqQuery(r)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		c := wordfreq.NewCounter(req.options()...)
+		if _, err := c.ReadFrom(http.MaxBytesReader(w, r.Body, s.cfg.MaxUploadBytes)); err != nil {
+			s.writeBodyError(w, err)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, req.response(c))
+		return
+	}
+
+	var req WordFreqRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	resp, err := runWordFreq(req)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// palindrome handles POST /palindrome.
+func (s *server) palindrome(w http.ResponseWriter, r *http.Request) {
+	if isPlainText(r) {
+		mode, err := scanMode(r.URL.Query().Get("scan"))
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		sc := palindrome.NewScanner(http.MaxBytesReader(w, r.Body, s.cfg.MaxUploadBytes), mode)
+		if v := r.URL.Query().Get("min_length"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				s.writeError(w, http.StatusBadRequest, fmt.Errorf("invalid min_length %q", v))
+				return
+			}
+			sc.MinLength = n
+		}
+		resp := ScanResponse{Findings: []palindrome.Finding{}}
+		for sc.Scan() {
+			if len(resp.Findings) == s.cfg.MaxFindings {
+				resp.Truncated = true
+				break
+			}
+			resp.Findings = append(resp.Findings, sc.Finding())
+		}
+		if err := sc.Err(); err != nil {
+			s.writeBodyError(w, err)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	var req PalindromeRequest
+	if !s.decode(w, r, &req) {
+		return
+	}
+	s.writeJSON(w, http.StatusOK, runPalindrome(req))
+}
+
+// batch handles POST /batch.
+func (s *server) batch(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Requests []BatchItem `json:"requests"`
+	}
+	if !s.decode(w, r, &req) {
+		return
+	}
+	if len(req.Requests) > s.cfg.MaxBatchItems {
+		s.writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("batch has %d requests, limit is %d", len(req.Requests), s.cfg.MaxBatchItems))
+		return
+	}
+
+	results := make([]BatchResult, len(req.Requests))
+	for i, item := range req.Requests {
+		switch item.Op {
+		case "wordfreq":
+			resp, err := runWordFreq(item.WordFreqRequest)
+			if err != nil {
+				results[i].Error = err.Error()
+				continue
+			}
+			results[i].Result = resp
+		case "palindrome":
+			results[i].Result = runPalindrome(PalindromeRequest{Text: item.Text, Longest: item.Longest})
+		default:
+			results[i].Error = fmt.Sprintf("unknown op %q", item.Op)
+		}
+	}
+	s.writeJSON(w, http.StatusOK, map[string]any{"results": results})
+}
+
+func runWordFreq(req WordFreqRequest) (WordFreqResponse, error) {
+	if req.Sort != "" && req.Sort != "count" && req.Sort != "word" {
+		return WordFreqResponse{}, fmt.Errorf("invalid sort %q, want count or word", req.Sort)
+	}
+	c := wordfreq.NewCounter(req.options()...)
+	c.AddText(req.Text)
+	return req.response(c), nil
+}
+
+func runPalindrome(req PalindromeRequest) PalindromeResponse {
+	resp := PalindromeResponse{Palindrome: palindrome.IsPalindrome(req.Text)}
+	if req.Longest {
+		m := palindrome.Longest(req.Text)
+		resp.Longest = &m
+	}
+	return resp
+}
+
+func (req WordFreqRequest) options() []wordfreq.Option {
+	opts := []wordfreq.Option{wordfreq.WithStopWords(req.StopWords...), wordfreq.WithMinLength(req.MinLength)}
+	if req.English {
+		opts = append(opts, wordfreq.WithStopWords(wordfreq.EnglishStopWords...))
+	}
+	if req.Stem {
+		opts = append(opts, wordfreq.WithStemmer(wordfreq.PorterStemmer{}))
+	}
+	return opts
+}
+
+func (req WordFreqRequest) response(c *wordfreq.Counter) WordFreqResponse {
+	var words []wordfreq.WordCount
+	if req.Top > 0 {
+		words = c.TopK(req.Top)
+	} else {
+		words = wordfreq.Sorted(c.Counts(), wordfreq.ByCount)
+	}
+	if req.Sort == "word" {
+		slices.SortFunc(words, func(a, b wordfreq.WordCount) int {
+			return strings.Compare(a.Word, b.Word)
+		})
+	}
+	if words == nil {
+		words = []wordfreq.WordCount{}
+	}
+	return WordFreqResponse{Total: c.Total(), Words: words}
+}
+
+// wordFreqQuery reads WordFreqRequest options from the query string of a text/plain upload.
+func wordFreqQuery(r *http.Request) (WordFreqRequest, error) {
+	q := r.URL.Query()
+	req := WordFreqRequest{Sort: q.Get("sort")}
+	if req.Sort != "" && req.Sort != "count" && req.Sort != "word" {
+		return req, fmt.Errorf("invalid sort %q, want count or word", req.Sort)
+	}
+	for _, name := range []string{"top", "min_length"} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return req, fmt.Errorf("invalid %s %q", name, v)
+		}
+		if name == "top" {
+			req.Top = n
+		} else {
+			req.MinLength = n
+		}
+	}
+	for _, w := range strings.Split(q.Get("stop_words"), ",") {
+		switch w = strings.TrimSpace(w); w {
+		case "":
+		case "english":
+			req.English = true
+		default:
+			req.StopWords = append(req.StopWords, w)
+		}
+	}
+	req.Stem = q.Get("stem") == "true"
+	return req, nil
+}
+
+func scanMode(name string) (palindrome.ScanMode, error) {
+	switch name {
+	case "", "words":
+		return palindrome.ScanWords, nil
+	case "lines":
+		return palindrome.ScanLines, nil
+	case "sentences":
+		return palindrome.ScanSentences, nil
+	}
+	return 0, fmt.Errorf("invalid scan %q, want words, lines or sentences", name)
+}
+
+func isPlainText(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "text/plain"
+}
+
+// decode reads a size-limited JSON body into v, writing an error response and
+// returning false if that fails.
+func (s *server) decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.cfg.MaxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		s.writeBodyError(w, err)
+		return false
+	}
+	return true
+}
+
+// writeBodyError reports a failure to read the request body, using 413 when
+// the size limit was hit.
+func (s *server) writeBodyError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		s.writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit))
+		return
+	}
+	s.writeError(w, http.StatusBadRequest, err)
+}
+
+func (s *server) writeError(w http.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func (s *server) writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		// The status line is already sent, so the client sees a cut-off body.
+		s.logf("server: writing response: %v", err)
+	}
+}
+
+func (s *server) logf(format string, args ...any) {
+	if s.cfg.ErrorLog != nil {
+		s.cfg.ErrorLog.Printf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
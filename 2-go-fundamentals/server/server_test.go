@@ -0,0 +1,168 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"go-fundamentals/wordfreq"
+)
+
+func post(t *testing.T, h http.Handler, path, contentType, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeBody[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(rec.Body.Bytes(), &v); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	return v
+}
+
+func TestWordFreq(t *testing.T) {
+	h := New(Config{})
+
+	rec := post(t, h, "/wordfreq", "application/json", `{"text": "The cat and the hat", "english_stop_words": true}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	resp := decodeBody[WordFreqResponse](t, rec)
+	expected := []wordfreq.WordCount{{Word: "cat", Count: 1}, {Word: "hat", Count: 1}}
+	if !slices.Equal(resp.Words, expected) || resp.Total != 2 {
+		t.Errorf("expected %v, got %+v", expected, resp)
+	}
+
+	rec = post(t, h, "/wordfreq?top=1&stop_words=a,b", "text/plain; charset=utf-8", "a b c c\nd c\n")
+	resp = decodeBody[WordFreqResponse](t, rec)
+	if rec.Code != http.StatusOK || !slices.Equal(resp.Words, []wordfreq.WordCount{{Word: "c", Count: 3}}) {
+		t.Errorf("unexpected upload response %d %+v", rec.Code, resp)
+	}
+
+	rec = post(t, h, "/wordfreq", "application/json", `{"text": "x", "sort": "random"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for bad sort, got %d", rec.Code)
+	}
+	rec = post(t, h, "/wordfreq", "application/json", `{"txt": "typo"}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"error"`) {
+		t.Errorf("expected 400 error for unknown field, got %d %s", rec.Code, rec.Body)
+	}
+}
+
+func TestPalindrome(t *testing.T) {
+	h := New(Config{})
+
+	rec := post(t, h, "/palindrome", "application/json", `{"text": "Ésope reste ici et se repose", "longest": true}`)
+	resp := decodeBody[PalindromeResponse](t, rec)
+	if rec.Code != http.StatusOK || !resp.Palindrome || resp.Longest == nil || resp.Longest.Text != "Ésope reste ici et se repose" {
+		t.Errorf("unexpected response %d %+v", rec.Code, resp)
+	}
+
+	rec = post(t, h, "/palindrome?scan=lines", "text/plain", "hello\nStep on no pets\n")
+	scan := decodeBody[ScanResponse](t, rec)
+	if rec.Code != http.StatusOK || len(scan.Findings) != 1 || scan.Findings[0].Line != 2 {
+		t.Errorf("unexpected scan response %d %+v", rec.Code, scan)
+	}
+
+	rec = post(t, New(Config{MaxFindings: 2}), "/palindrome?scan=words", "text/plain", "anna otto bob kayak")
+	scan = decodeBody[ScanResponse](t, rec)
+	if rec.Code != http.StatusOK || len(scan.Findings) != 2 || !scan.Truncated {
+		t.Errorf("expected 2 findings and truncated, got %d %+v", rec.Code, scan)
+	}
+
+	rec = post(t, h, "/palindrome?scan=pages", "text/plain", "x")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for bad scan mode, got %d", rec.Code)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	h := New(Config{MaxBatchItems: 3})
+
+	body := `{"requests": [
+		{"op": "wordfreq", "text": "go go"},
+		{"op": "palindrome", "text": "racecar"},
+		{"op": "anagram", "text": "x"}
+	]}`
+	rec := post(t, h, "/batch", "application/json", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	resp := decodeBody[struct {
+		Results []struct {
+			Result json.RawMessage `json:"result"`
+			Error  string          `json:"error"`
+		} `json:"results"`
+	}](t, rec)
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+	if !strings.Contains(string(resp.Results[0].Result), `"count":2`) ||
+		!strings.Contains(string(resp.Results[1].Result), `"palindrome":true`) ||
+		resp.Results[2].Error == "" {
+		t.Errorf("unexpected batch results %s", rec.Body)
+	}
+
+	tooMany := `{"requests": [{"op": "palindrome"}, {"op": "palindrome"}, {"op": "palindrome"}, {"op": "palindrome"}]}`
+	if rec := post(t, h, "/batch", "application/json", tooMany); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for oversized batch, got %d", rec.Code)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	h := New(Config{MaxBodyBytes: 32, MaxUploadBytes: 64})
+
+	rec := post(t, h, "/palindrome", "application/json", `{"text": "`+strings.Repeat("a", 100)+`"}`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for large JSON body, got %d", rec.Code)
+	}
+	rec = post(t, h, "/wordfreq", "text/plain", strings.Repeat("word ", 100))
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413 for large upload, got %d", rec.Code)
+	}
+	rec = post(t, h, "/wordfreq", "text/plain", strings.Repeat("word ", 10))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 for upload within limit, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/wordfreq", nil)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405 for GET, got %d", rec.Code)
+	}
+}
+
+// failingWriter is a ResponseWriter whose body writes always fail, as when
+// the client has gone away.
+type failingWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestErrorLog(t *testing.T) {
+	var logged bytes.Buffer
+	h := New(Config{ErrorLog: log.New(&logged, "", 0)})
+
+	req := httptest.NewRequest(http.MethodPost, "/palindrome", strings.NewReader(`{"text": "kayak"}`))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(failingWriter{httptest.NewRecorder()}, req)
+	if expected := "server: writing response: connection reset\n"; logged.String() != expected {
+		t.Errorf("expected %q, got %q", expected, logged.String())
+	}
+}
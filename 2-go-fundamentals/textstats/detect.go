@@ -0,0 +1,265 @@
+// Package textstats detects the language of a text and reports word,
+// sentence and readability statistics for each language it contains.
+//
+// Detection compares character n-gram profiles (Cavnar and Trenkle, 1994).
+// The profiles are built at start-up from the sample texts embedded under
+// profiles/, one file per language named after its ISO 639-1 code; dropping
+// another sample there adds a language.
+//
+// The bundled samples cover English, French, Spanish, German and Italian,
+// which are told apart by their profiles, and Amharic, which is recognized
+// by its Ethiopic script alone. Each sample is a few kilobytes, enough for
+// sentences of five words or more to be detected reliably; two- or
+// three-word inputs such as "Niente male" are often confused between the
+// Romance languages, which is why Analyze gives sentences shorter than 20
+// letters the language of the whole text. Text written mostly in another
+// script that Detect recognizes (Cyrillic, Greek, Arabic, Hebrew, Devanagari
+// or Han) is reported as Unknown instead of being forced onto a Latin-script
+// profile.
+package textstats
+
+import (
+	"cmp"
+	"embed"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"go-fundamentals/wordfreq"
+)
+
+// Unknown is the language reported for text that has no letters, or none in
+// a script any bundled profile covers.
+const Unknown = "und"
+
+const (
+	// profileSize is how many of the most frequent n-grams a profile keeps.
+	profileSize = 300
+	// maxNGram is the longest n-gram, in runes, a profile counts.
+	maxNGram = 3
+	// temperature scales normalized distances before the softmax that turns
+	// them into confidences; smaller values make the best match stand out more.
+	temperature = 0.02
+)
+
+//go:embed profiles/*.txt
+var profileFiles embed.FS
+
+// profile maps an n-gram to its rank, 0 being the most frequent.
+type profile map[string]int
+
+type language struct {
+	code    string
+	script  string
+	profile profile
+}
+
+var languages = loadLanguages()
+
+// scripts are the writing systems detection can tell apart. A text is only
+// compared against the languages written in its dominant script.
+var scripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Ethiopic", unicode.Ethiopic},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+	{"Arabic", unicode.Arabic},
+	{"Hebrew", unicode.Hebrew},
+	{"Devanagari", unicode.Devanagari},
+	{"Han", unicode.Han},
+}
+
+func loadLanguages() []language {
+	entries, err := profileFiles.ReadDir("profiles")
+	if err != nil {
+		panic(err)
+	}
+	var langs []language
+	for _, e := range entries {
+		data, err := profileFiles.ReadFile(path.Join("profiles", e.Name()))
+		if err != nil {
+			panic(err)
+		}
+		text := string(data)
+		langs = append(langs, language{
+			code:    strings.TrimSuffix(e.Name(), ".txt"),
+			script:  dominantScript(text),
+			profile: newProfile(text),
+		})
+	}
+	return langs
+}
+
+// Languages returns the codes of the languages Detect can report, sorted.
+func Languages() []string {
+	codes := make([]string, len(languages))
+	for i, l := range languages {
+		codes[i] = l.code
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// Score is a candidate language for a text. Confidence is between 0 and 1.
+type Score struct {
+	Language   string  `json:"language"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Detect returns the most likely language of text, or Unknown with zero
+// confidence.
+func Detect(text string) Score {
+	scores := DetectAll(text)
+	if len(scores) == 0 {
+		return Score{Language: Unknown}
+	}
+	return scores[0]
+}
+
+// DetectAll returns every bundled language written in the dominant script of
+// text, most likely first. The confidences sum to 1. It returns nil when no
+// language applies.
+func DetectAll(text string) []Score {
+	script := dominantScript(text)
+	if script == "" {
+		return nil
+	}
+
+	doc := newProfile(text)
+	var candidates []language
+	for _, l := range languages {
+		if l.script == script {
+			candidates = append(candidates, l)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	distances := make([]float64, len(candidates))
+	best := math.Inf(1)
+	for i, l := range candidates {
+		distances[i] = outOfPlace(doc, l.profile)
+		best = min(best, distances[i])
+	}
+
+	scores := make([]Score, len(candidates))
+	var total float64
+	for i, l := range candidates {
+		w := math.Exp(-(distances[i] - best) / temperature)
+		scores[i] = Score{Language: l.code, Confidence: w}
+		total += w
+	}
+	for i := range scores {
+		scores[i].Confidence /= total
+	}
+	slices.SortFunc(scores, func(a, b Score) int {
+		if c := cmp.Compare(b.Confidence, a.Confidence); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return scores
+}
+
+// newProfile ranks the 1- to maxNGram-grams of the lowercased words of text.
+// Words are padded with '_' so that n-grams at word boundaries, which are
+// among the most telling, are counted separately from those inside words.
+func newProfile(text string) profile {
+	counts := make(map[string]int)
+	for _, word := range (wordfreq.UnicodeTokenizer{}).Tokenize(text) {
+		if !hasLetter(word) {
+			continue
+		}
+		runes := []rune("_" + strings.ToLower(word) + "_")
+		for n := 1; n <= maxNGram; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				gram := string(runes[i : i+n])
+				if gram != "_" {
+					counts[gram]++
+				}
+			}
+		}
+	}
+
+	grams := make([]string, 0, len(counts))
+	for g := range counts {
+		grams = append(grams, g)
+	}
+	slices.SortFunc(grams, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	if len(grams) > profileSize {
+		grams = grams[:profileSize]
+	}
+
+	p := make(profile, len(grams))
+	for rank, g := range grams {
+		p[g] = rank
+	}
+	return p
+}
+
+// outOfPlace returns the Cavnar-Trenkle distance between a document profile
+// and a language profile, normalized to [0, 1]: each n-gram of doc costs the
+// difference between its two ranks, or profileSize if lang lacks it.
+func outOfPlace(doc, lang profile) float64 {
+	if len(doc) == 0 {
+		return 1
+	}
+	var d int
+	for gram, rank := range doc {
+		if r, ok := lang[gram]; ok {
+			d += min(abs(rank-r), profileSize)
+		} else {
+			d += profileSize
+		}
+	}
+	return float64(d) / float64(len(doc)*profileSize)
+}
+
+// dominantScript returns the name of the script most letters of text are
+// written in, or "" if text has no letters in a known script.
+func dominantScript(text string) string {
+	counts := make([]int, len(scripts))
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		for i, s := range scripts {
+			if unicode.Is(s.table, r) {
+				counts[i]++
+				break
+			}
+		}
+	}
+	best := -1
+	for i, n := range counts {
+		if n > 0 && (best < 0 || n > counts[best]) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return scripts[best].name
+}
+
+func hasLetter(word string) bool {
+	return strings.IndexFunc(word, unicode.IsLetter) >= 0
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
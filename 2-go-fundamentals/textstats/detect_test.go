@@ -0,0 +1,69 @@
+package textstats
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestLanguages(t *testing.T) {
+	expected := []string{"am", "de", "en", "es", "fr", "it"}
+	if got := Languages(); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"english", "The quick brown fox jumps over the lazy dog while the farmer watches from his house.", "en"},
+		{"french", "Le renard brun rapide saute par-dessus le chien paresseux pendant que le fermier regarde.", "fr"},
+		{"spanish", "El rápido zorro marrón salta sobre el perro perezoso mientras el granjero mira desde su casa.", "es"},
+		{"german", "Der schnelle braune Fuchs springt über den faulen Hund, während der Bauer aus seinem Haus zuschaut.", "de"},
+		{"italian", "La volpe marrone veloce salta sopra il cane pigro mentre il contadino guarda dalla sua casa.", "it"},
+		{"short english", "The delivery was late.", "en"},
+		{"short french", "La livraison était en retard.", "fr"},
+		{"short spanish", "¿Dónde está mi pedido?", "es"},
+		{"short german", "Wo ist meine Bestellung?", "de"},
+		{"short italian", "Il personale è stato scortese.", "it"},
+		{"amharic", "ሰላም ለዓለም፤ እንዴት ነህ?", "am"},
+		{"no profile for script", "Привет, мир", Unknown},
+		{"no letters", "12345 !!!", Unknown},
+		{"empty", "", Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Detect(tt.text).Language; got != tt.expected {
+				t.Errorf("expected %q, got %q (scores %v)", tt.expected, got, DetectAll(tt.text))
+			}
+		})
+	}
+}
+
+func TestDetectAll(t *testing.T) {
+	scores := DetectAll("Bitte schließen Sie die Tür, wenn Sie das Zimmer verlassen.")
+	if len(scores) != 5 {
+		t.Fatalf("expected the 5 Latin-script languages, got %v", scores)
+	}
+	if scores[0].Language != "de" || scores[0].Confidence < 0.5 {
+		t.Errorf("expected de first with confidence above 0.5, got %v", scores[0])
+	}
+	var total float64
+	for i, s := range scores {
+		total += s.Confidence
+		if i > 0 && s.Confidence > scores[i-1].Confidence {
+			t.Errorf("scores not in descending order: %v", scores)
+		}
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("expected confidences to sum to 1, got %v", total)
+	}
+
+	if got := DetectAll("ዛሬ ዝናብ ይዘንባል"); len(got) != 1 || got[0] != (Score{"am", 1}) {
+		t.Errorf("expected only am with confidence 1, got %v", got)
+	}
+}
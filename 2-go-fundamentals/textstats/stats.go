@@ -0,0 +1,205 @@
+package textstats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go-fundamentals/wordfreq"
+)
+
+// minDetectLetters is the number of letters below which a sentence is too
+// short to detect reliably on its own.
+const minDetectLetters = 20
+
+// Stats summarizes the sentences of one language in a text.
+type Stats struct {
+	Language  string `json:"language"`
+	Sentences int    `json:"sentences"`
+	Words     int    `json:"words"`
+	// AverageWordLength is measured in letters.
+	AverageWordLength float64 `json:"average_word_length"`
+	// LIX is Björnsson's readability index: words per sentence plus the
+	// percentage of words longer than six letters. It needs no syllable
+	// counts, so it is reported for every language; below 30 is very easy,
+	// above 60 very hard.
+	LIX float64 `json:"lix"`
+	// FleschReadingEase uses the adaptation of the Flesch formula for the
+	// language (Amstad for German, Kandel and Moles for French, Fernández
+	// Huerta for Spanish, Franchini for Italian). Higher is easier. It is nil
+	// for languages without one.
+	FleschReadingEase *float64 `json:"flesch_reading_ease,omitempty"`
+}
+
+// Analyze splits text into sentences, detects the language of each and
+// returns the statistics of every language found, the one with the most
+// words first. Sentences without any words are ignored.
+func Analyze(text string) []Stats {
+	fallback := Detect(text).Language
+
+	type totals struct {
+		sentences, words, letters, longWords, syllables int
+	}
+	byLang := make(map[string]*totals)
+	for _, sentence := range Sentences(text) {
+		var words []string
+		for _, w := range (wordfreq.UnicodeTokenizer{}).Tokenize(sentence) {
+			if hasLetter(w) {
+				words = append(words, w)
+			}
+		}
+		if len(words) == 0 {
+			continue
+		}
+
+		lang := sentenceLanguage(sentence, fallback)
+		t := byLang[lang]
+		if t == nil {
+			t = &totals{}
+			byLang[lang] = t
+		}
+		t.sentences++
+		for _, w := range words {
+			n := countLetters(w)
+			t.words++
+			t.letters += n
+			if n > 6 {
+				t.longWords++
+			}
+			t.syllables += Syllables(w, lang)
+		}
+	}
+
+	stats := make([]Stats, 0, len(byLang))
+	for lang, t := range byLang {
+		words, sentences := float64(t.words), float64(t.sentences)
+		s := Stats{
+			Language:          lang,
+			Sentences:         t.sentences,
+			Words:             t.words,
+			AverageWordLength: float64(t.letters) / words,
+			LIX:               words/sentences + 100*float64(t.longWords)/words,
+		}
+		if f, ok := flesch[lang]; ok {
+			ease := f(words/sentences, float64(t.syllables)/words)
+			s.FleschReadingEase = &ease
+		}
+		stats = append(stats, s)
+	}
+	slices.SortFunc(stats, func(a, b Stats) int {
+		if c := cmp.Compare(b.Words, a.Words); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return stats
+}
+
+// sentenceLanguage detects the language of sentence. A short sentence is
+// attributed to fallback, the language of the whole text, unless its script
+// rules that out or leaves only one candidate.
+func sentenceLanguage(sentence, fallback string) string {
+	scores := DetectAll(sentence)
+	switch {
+	case len(scores) == 0:
+		return Unknown
+	case len(scores) == 1 || countLetters(sentence) >= minDetectLetters:
+		return scores[0].Language
+	}
+	for _, s := range scores {
+		if s.Language == fallback {
+			return fallback
+		}
+	}
+	return scores[0].Language
+}
+
+// flesch maps a language to its Flesch Reading Ease formula, given the
+// average sentence length in words and the average syllables per word.
+var flesch = map[string]func(wordsPerSentence, syllablesPerWord float64) float64{
+	"en": func(asl, asw float64) float64 { return 206.835 - 1.015*asl - 84.6*asw },
+	"de": func(asl, asw float64) float64 { return 180 - asl - 58.5*asw },
+	"fr": func(asl, asw float64) float64 { return 207 - 1.015*asl - 73.6*asw },
+	"es": func(asl, asw float64) float64 { return 206.84 - 1.02*asl - 60*asw },
+	"it": func(asl, asw float64) float64 { return 217 - 1.3*asl - 60*asw },
+}
+
+// Sentences splits text at sentence terminators (., !, ?, the Ethiopic ። and
+// the like) that are followed by a space or the end of the text, and at blank
+// lines. A terminator inside a token such as "3.14" does not split.
+// The sentences are trimmed and empty ones are dropped.
+func Sentences(text string) []string {
+	var sentences []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+
+	start := 0
+	for i, r := range text {
+		next := i + utf8.RuneLen(r)
+		switch {
+		case unicode.Is(unicode.Sentence_Terminal, r):
+			after, _ := utf8.DecodeRuneInString(text[next:])
+			if next == len(text) || unicode.IsSpace(after) {
+				add(text[start:next])
+				start = next
+			}
+		case r == '\n' && isBlankLineAhead(text[next:]):
+			add(text[start:next])
+			start = next
+		}
+	}
+	add(text[start:])
+	return sentences
+}
+
+// isBlankLineAhead reports whether rest, which follows a newline, begins with
+// a line containing only whitespace.
+func isBlankLineAhead(rest string) bool {
+	line, _, found := strings.Cut(rest, "\n")
+	return found && strings.TrimSpace(line) == ""
+}
+
+// Syllables estimates the syllables in word for the given language by
+// counting groups of vowels. In English a final silent "e" is not counted.
+// In Ethiopic script every syllable is written as one character, so each
+// letter counts as one. Every word has at least one syllable.
+func Syllables(word, lang string) int {
+	word = strings.ToLower(word)
+	n := 0
+	inVowel := false
+	for _, r := range word {
+		if unicode.Is(unicode.Ethiopic, r) && unicode.IsLetter(r) {
+			n++
+			continue
+		}
+		v := isVowel(r)
+		if v && !inVowel {
+			n++
+		}
+		inVowel = v
+	}
+	if lang == "en" && n > 1 && strings.HasSuffix(word, "e") &&
+		!strings.HasSuffix(word, "le") && !strings.HasSuffix(word, "ee") {
+		n--
+	}
+	return max(n, 1)
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aeiouyàáâãäåæèéêëìíîïòóôõöøœùúûüý", r)
+}
+
+func countLetters(s string) int {
+	n := 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			n++
+		}
+	}
+	return n
+}
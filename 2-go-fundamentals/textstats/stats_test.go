@@ -0,0 +1,98 @@
+package textstats
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"terminators", "One. Two! Three? Four", []string{"One.", "Two!", "Three?", "Four"}},
+		{"decimal", "Pi is 3.14 or so. Yes.", []string{"Pi is 3.14 or so.", "Yes."}},
+		{"ethiopic full stop", "ሰላም ነው። ደህና ነኝ።", []string{"ሰላም ነው።", "ደህና ነኝ።"}},
+		{"blank line", "A heading\n\nThe body", []string{"A heading", "The body"}},
+		{"line break", "one line\ncontinues", []string{"one line\ncontinues"}},
+		{"empty", "  \n ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sentences(tt.text); !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		word     string
+		lang     string
+		expected int
+	}{
+		{"cat", "en", 1},
+		{"table", "en", 2},
+		{"make", "en", 1},
+		{"reading", "en", 2},
+		{"beautiful", "en", 3},
+		{"biblioteca", "es", 4},
+		{"Äpfel", "de", 2},
+		{"ሰላም", "am", 3},
+		{"x", "en", 1},
+	}
+
+	for _, tt := range tests {
+		if got := Syllables(tt.word, tt.lang); got != tt.expected {
+			t.Errorf("Syllables(%q, %q): expected %d, got %d", tt.word, tt.lang, tt.expected, got)
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	text := "The cat sat on the mat. It was happy! " +
+		"Le chat est assis sur le tapis et il est très content de sa journée. " +
+		"ሰላም ለዓለም። እንዴት ነህ?"
+	stats := Analyze(text)
+
+	var langs []string
+	for _, s := range stats {
+		langs = append(langs, s.Language)
+	}
+	if expected := []string{"fr", "en", "am"}; !slices.Equal(langs, expected) {
+		t.Fatalf("expected languages %v, got %v", expected, langs)
+	}
+
+	fr, en, am := stats[0], stats[1], stats[2]
+	if fr.Sentences != 1 || fr.Words != 15 {
+		t.Errorf("fr: expected 1 sentence and 15 words, got %+v", fr)
+	}
+	if en.Sentences != 2 || en.Words != 9 {
+		t.Errorf("en: expected 2 sentences and 9 words, got %+v", en)
+	}
+	if am.Sentences != 2 || am.Words != 4 || am.FleschReadingEase != nil {
+		t.Errorf("am: expected 2 sentences, 4 words and no Flesch score, got %+v", am)
+	}
+
+	// 9 words of 27 letters over 2 sentences, none longer than 6 letters,
+	// and 10 syllables ("happy" has two).
+	if en.AverageWordLength != 3 {
+		t.Errorf("en: expected average word length 3, got %v", en.AverageWordLength)
+	}
+	if math.Abs(en.LIX-4.5) > 1e-9 {
+		t.Errorf("en: expected LIX 4.5, got %v", en.LIX)
+	}
+	if ease := 206.835 - 1.015*4.5 - 84.6*10/9; en.FleschReadingEase == nil || math.Abs(*en.FleschReadingEase-ease) > 1e-9 {
+		t.Errorf("en: expected Flesch reading ease %v, got %v", ease, en.FleschReadingEase)
+	}
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	if got := Analyze("... !!!"); len(got) != 0 {
+		t.Errorf("expected no stats, got %+v", got)
+	}
+}
@@ -0,0 +1,180 @@
+package wordfreq
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+)
+
+// ErrDocumentNotFound is returned when a Corpus has no document with the given ID.
+var ErrDocumentNotFound = errors.New("wordfreq: document not found")
+
+// Match is a document ID with its similarity score.
+type Match struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+// Corpus indexes a set of documents for TF-IDF weighting and similarity search.
+// It is not safe for concurrent use.
+type Corpus struct {
+	opts []Option
+	docs map[string]document
+	df   map[string]int // word -> number of documents containing it
+}
+
+type document struct {
+	counts map[string]int
+	length int
+}
+
+// NewCorpus returns an empty Corpus. opts control how documents are tokenized
+// and normalized, exactly as for WordFrequencyCount.
+func NewCorpus(opts ...Option) *Corpus {
+	return &Corpus{
+		opts: opts,
+		docs: make(map[string]document),
+		df:   make(map[string]int),
+	}
+}
+
+// AddDocument indexes text under id, replacing any document with the same id.
+func (c *Corpus) AddDocument(id, text string) {
+	c.RemoveDocument(id)
+
+	counts := WordFrequencyCount(text, c.opts...)
+	length := 0
+	for word, n := range counts {
+		c.df[word]++
+		length += n
+	}
+	c.docs[id] = document{counts: counts, length: length}
+}
+
+// RemoveDocument drops the document with the given id, if present.
+func (c *Corpus) RemoveDocument(id string) {
+	doc, ok := c.docs[id]
+	if !ok {
+		return
+	}
+	for word := range doc.counts {
+		if c.df[word]--; c.df[word] == 0 {
+			delete(c.df, word)
+		}
+	}
+	delete(c.docs, id)
+}
+
+// Len returns the number of documents in the corpus.
+func (c *Corpus) Len() int {
+	return len(c.docs)
+}
+
+// DocumentFrequency returns how many documents contain word. word must already
+// be normalized the way the corpus options normalize text.
+func (c *Corpus) DocumentFrequency(word string) int {
+	return c.df[word]
+}
+
+// IDF returns the smoothed inverse document frequency ln((1+N)/(1+df)) + 1,
+// which stays positive for words present in every document.
+func (c *Corpus) IDF(word string) float64 {
+	return math.Log(float64(1+len(c.docs))/float64(1+c.df[word])) + 1
+}
+
+// TFIDF returns the TF-IDF vector of a document, where term frequency is the
+// word's count divided by the document length.
+func (c *Corpus) TFIDF(id string) (map[string]float64, error) {
+	doc, ok := c.docs[id]
+	if !ok {
+		return nil, ErrDocumentNotFound
+	}
+	return c.vector(doc), nil
+}
+
+// Similarity returns the cosine similarity of the TF-IDF vectors of two
+// documents, from 0 (nothing in common) to 1 (same weighted vocabulary).
+func (c *Corpus) Similarity(a, b string) (float64, error) {
+	va, err := c.TFIDF(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := c.TFIDF(b)
+	if err != nil {
+		return 0, err
+	}
+	return cosine(va, vb), nil
+}
+
+// MostSimilar returns up to k other documents ranked by similarity to id.
+func (c *Corpus) MostSimilar(id string, k int) ([]Match, error) {
+	v, err := c.TFIDF(id)
+	if err != nil {
+		return nil, err
+	}
+	return c.rank(v, k, id), nil
+}
+
+// Search ranks the corpus documents against free text and returns up to k matches.
+// The query is normalized with the corpus options but does not change document frequencies.
+func (c *Corpus) Search(query string, k int) []Match {
+	counts := WordFrequencyCount(query, c.opts...)
+	length := 0
+	for _, n := range counts {
+		length += n
+	}
+	return c.rank(c.vector(document{counts: counts, length: length}), k, "")
+}
+
+func (c *Corpus) vector(doc document) map[string]float64 {
+	v := make(map[string]float64, len(doc.counts))
+	for word, n := range doc.counts {
+		v[word] = float64(n) / float64(doc.length) * c.IDF(word)
+	}
+	return v
+}
+
+// rank scores every document except skip against v, best first, ties by ID.
+func (c *Corpus) rank(v map[string]float64, k int, skip string) []Match {
+	matches := make([]Match, 0, len(c.docs))
+	for id, doc := range c.docs {
+		if id == skip {
+			continue
+		}
+		matches = append(matches, Match{ID: id, Score: cosine(v, c.vector(doc))})
+	}
+	slices.SortFunc(matches, func(a, b Match) int {
+		if s := cmp.Compare(b.Score, a.Score); s != 0 {
+			return s
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if k >= 0 && k < len(matches) {
+		matches = matches[:k]
+	}
+	return matches
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var dot float64
+	for word, x := range a {
+		dot += x * b[word]
+	}
+	na, nb := norm(a), norm(b)
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (na * nb)
+}
+
+func norm(v map[string]float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
@@ -0,0 +1,98 @@
+package wordfreq
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func newTicketCorpus() *Corpus {
+	c := NewCorpus(WithStopWords(EnglishStopWords...), WithStemmer(PorterStemmer{}))
+	c.AddDocument("login", "I cannot log in, the login page rejects my password")
+	c.AddDocument("password", "Password reset email never arrives after I reset my password")
+	c.AddDocument("billing", "I was charged twice on my invoice this month")
+	c.AddDocument("refund", "Please refund the duplicate charge on my invoice")
+	return c
+}
+
+func TestCorpusTFIDF(t *testing.T) {
+	c := NewCorpus()
+	c.AddDocument("a", "apple apple banana")
+	c.AddDocument("b", "banana cherry")
+
+	if c.Len() != 2 || c.DocumentFrequency("banana") != 2 || c.DocumentFrequency("apple") != 1 {
+		t.Fatalf("unexpected document frequencies: banana=%d apple=%d", c.DocumentFrequency("banana"), c.DocumentFrequency("apple"))
+	}
+
+	v, err := c.TFIDF("a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantApple := 2.0 / 3 * (math.Log(3.0/2) + 1)
+	wantBanana := 1.0 / 3 * 1
+	if math.Abs(v["apple"]-wantApple) > 1e-12 || math.Abs(v["banana"]-wantBanana) > 1e-12 {
+		t.Errorf("expected apple=%v banana=%v, got %v", wantApple, wantBanana, v)
+	}
+
+	if _, err := c.TFIDF("missing"); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestCorpusReplaceAndRemove(t *testing.T) {
+	c := NewCorpus()
+	c.AddDocument("a", "apple")
+	c.AddDocument("a", "banana")
+	if c.Len() != 1 || c.DocumentFrequency("apple") != 0 || c.DocumentFrequency("banana") != 1 {
+		t.Errorf("replacing a document did not update frequencies")
+	}
+	c.RemoveDocument("a")
+	c.RemoveDocument("a")
+	if c.Len() != 0 || c.DocumentFrequency("banana") != 0 {
+		t.Errorf("removing a document did not update frequencies")
+	}
+}
+
+func TestCorpusSimilarity(t *testing.T) {
+	c := newTicketCorpus()
+
+	self, err := c.Similarity("login", "login")
+	if err != nil || math.Abs(self-1) > 1e-12 {
+		t.Errorf("expected self-similarity 1, got %v (%v)", self, err)
+	}
+
+	related, _ := c.Similarity("billing", "refund")
+	unrelated, _ := c.Similarity("billing", "login")
+	if related <= unrelated {
+		t.Errorf("expected billing~refund (%v) > billing~login (%v)", related, unrelated)
+	}
+
+	if _, err := c.Similarity("login", "missing"); !errors.Is(err, ErrDocumentNotFound) {
+		t.Errorf("expected ErrDocumentNotFound, got %v", err)
+	}
+}
+
+func TestCorpusMostSimilarAndSearch(t *testing.T) {
+	c := newTicketCorpus()
+
+	matches, err := c.MostSimilar("password", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matches) != 2 || matches[0].ID != "login" {
+		t.Errorf("expected login to be most similar to password, got %v", matches)
+	}
+	for _, m := range matches {
+		if m.ID == "password" {
+			t.Errorf("document matched itself: %v", matches)
+		}
+	}
+
+	results := c.Search("duplicate charges on invoice", 1)
+	if len(results) != 1 || results[0].ID != "refund" {
+		t.Errorf("expected refund as best match, got %v", results)
+	}
+	if all := c.Search("invoice", -1); len(all) != 4 {
+		t.Errorf("expected all documents for negative k, got %v", all)
+	}
+}
@@ -0,0 +1,195 @@
+package wordfreq
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ErrIncompatible is returned by Merge when two counters cannot be combined.
+var ErrIncompatible = errors.New("wordfreq: incompatible counters")
+
+// WordCount pairs a word with its frequency.
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Counter accumulates word frequencies from text streams. It is not safe for
+// concurrent use; count in separate counters and Merge them instead.
+type Counter struct {
+	cfg    *config
+	counts map[string]int // exact mode
+	sketch *countMinSketch
+	top    *topTracker
+	total  int
+}
+
+// NewCounter returns an empty Counter. By default it counts exactly.
+func NewCounter(opts ...Option) *Counter {
+	cfg := newConfig(opts)
+	if cfg.sketchWidth > 0 && cfg.sketchDepth > 0 {
+		return &Counter{
+			cfg:    cfg,
+			sketch: newCountMinSketch(cfg.sketchWidth, cfg.sketchDepth),
+			top:    newTopTracker(max(cfg.sketchCapacity, 1)),
+		}
+	}
+	return &Counter{cfg: cfg, counts: make(map[string]int)}
+}
+
+// Add records n occurrences of word as is, without normalization.
+func (c *Counter) Add(word string, n int) {
+	if n <= 0 {
+		return
+	}
+	c.total += n
+	if c.sketch == nil {
+		c.counts[word] += n
+		return
+	}
+	c.top.offer(word, c.sketch.add(word, n))
+}
+
+// AddText counts every word in text.
+func (c *Counter) AddText(text string) {
+	for _, word := range c.cfg.words(text) {
+		c.Add(word, 1)
+	}
+}
+
+// ReadFrom counts the words in r one line at a time, so only the current line
+// is held in memory. It implements io.ReaderFrom.
+func (c *Counter) ReadFrom(r io.Reader) (int64, error) {
+	br := bufio.NewReader(r)
+	var read int64
+	for {
+		line, err := br.ReadString('\n')
+		read += int64(len(line))
+		c.AddText(line)
+		if err == io.EOF {
+			return read, nil
+		}
+		if err != nil {
+			return read, err
+		}
+	}
+}
+
+// Count returns how often word was seen. In sketch mode this is an upper-bound estimate.
+func (c *Counter) Count(word string) int {
+	if c.sketch == nil {
+		return c.counts[word]
+	}
+	return c.sketch.estimate(word)
+}
+
+// Total returns the number of words counted.
+func (c *Counter) Total() int {
+	return c.total
+}
+
+// Len returns the number of distinct words known to the counter. In sketch mode
+// only the tracked heavy hitters are known.
+func (c *Counter) Len() int {
+	if c.sketch == nil {
+		return len(c.counts)
+	}
+	return c.top.Len()
+}
+
+// Counts returns a copy of the word counts. In sketch mode it contains only the
+// tracked heavy hitters with their estimated counts.
+func (c *Counter) Counts() map[string]int {
+	out := make(map[string]int, c.Len())
+	c.each(func(word string, count int) {
+		out[word] = count
+	})
+	return out
+}
+
+// TopK returns the k most frequent words, most frequent first. Ties are broken
+// alphabetically so the result is deterministic.
+func (c *Counter) TopK(k int) []WordCount {
+	if k <= 0 {
+		return nil
+	}
+	h := &minHeap{}
+	c.each(func(word string, count int) {
+		wc := WordCount{Word: word, Count: count}
+		if h.Len() < k {
+			heap.Push(h, wc)
+		} else if ranksBefore(wc, (*h)[0]) {
+			(*h)[0] = wc
+			heap.Fix(h, 0)
+		}
+	})
+
+	out := []WordCount(*h)
+	slices.SortFunc(out, compareWordCounts)
+	return out
+}
+
+// Merge adds the counts from other into c. Exact counters merge with exact
+// counters; sketch counters merge only with sketches of the same dimensions.
+func (c *Counter) Merge(other *Counter) error {
+	switch {
+	case c.sketch == nil && other.sketch == nil:
+		for word, n := range other.counts {
+			c.counts[word] += n
+		}
+	case c.sketch != nil && other.sketch != nil:
+		if err := c.sketch.merge(other.sketch); err != nil {
+			return err
+		}
+		for _, e := range slices.Concat(c.top.entries, other.top.entries) {
+			c.top.offer(e.Word, c.sketch.estimate(e.Word))
+		}
+	default:
+		return ErrIncompatible
+	}
+	c.total += other.total
+	return nil
+}
+
+func (c *Counter) each(fn func(word string, count int)) {
+	if c.sketch == nil {
+		for word, n := range c.counts {
+			fn(word, n)
+		}
+		return
+	}
+	for _, e := range c.top.entries {
+		fn(e.Word, e.Count)
+	}
+}
+
+// ranksBefore reports whether a should be listed before b: higher count first,
+// then alphabetical.
+func ranksBefore(a, b WordCount) bool {
+	return compareWordCounts(a, b) < 0
+}
+
+func compareWordCounts(a, b WordCount) int {
+	if a.Count != b.Count {
+		return b.Count - a.Count
+	}
+	return strings.Compare(a.Word, b.Word)
+}
+
+// minHeap keeps the lowest-ranked WordCount at the root.
+type minHeap []WordCount
+
+func (h minHeap) Len() int           { return len(h) }
+func (h minHeap) Less(i, j int) bool { return ranksBefore(h[j], h[i]) }
+func (h minHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x any)        { *h = append(*h, x.(WordCount)) }
+func (h *minHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,121 @@
+package wordfreq
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCounterReadFrom(t *testing.T) {
+	input := "the cat sat\non the mat\n\nThe end"
+	c := NewCounter()
+	n, err := c.ReadFrom(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(len(input)) {
+		t.Errorf("expected %d bytes read, got %d", len(input), n)
+	}
+	if !maps.Equal(c.Counts(), WordFrequencyCount(input)) {
+		t.Errorf("expected %v, got %v", WordFrequencyCount(input), c.Counts())
+	}
+	if c.Total() != 8 || c.Count("the") != 3 {
+		t.Errorf("unexpected totals: total=%d the=%d", c.Total(), c.Count("the"))
+	}
+}
+
+func TestCounterTopK(t *testing.T) {
+	c := NewCounter()
+	c.AddText("b b b a a c c d")
+
+	expected := []WordCount{{"b", 3}, {"a", 2}, {"c", 2}}
+	if got := c.TopK(3); !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := c.TopK(10); len(got) != 4 {
+		t.Errorf("expected all 4 words, got %v", got)
+	}
+	if got := c.TopK(0); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
+
+func TestCounterMerge(t *testing.T) {
+	a, b := NewCounter(), NewCounter()
+	a.AddText("x y")
+	b.AddText("y z z")
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]int{"x": 1, "y": 2, "z": 2}
+	if !maps.Equal(a.Counts(), expected) || a.Total() != 5 {
+		t.Errorf("expected %v (total 5), got %v (total %d)", expected, a.Counts(), a.Total())
+	}
+
+	if err := a.Merge(NewCounter(WithSketch(64, 4, 8))); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("expected ErrIncompatible, got %v", err)
+	}
+	s := NewCounter(WithSketch(64, 4, 8))
+	if err := s.Merge(NewCounter(WithSketch(32, 4, 8))); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("expected ErrIncompatible for mismatched sketches, got %v", err)
+	}
+}
+
+// zipfText builds text where word i occurs roughly 1000/(i+1) times.
+func zipfText(words int) string {
+	var sb strings.Builder
+	for i := range words {
+		for range 1000 / (i + 1) {
+			fmt.Fprintf(&sb, "w%d ", i)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func TestCounterSketch(t *testing.T) {
+	text := zipfText(500)
+	exact := NewCounter()
+	exact.AddText(text)
+
+	sketch := NewCounter(WithSketch(512, 5, 20))
+	sketch.AddText(text)
+
+	if sketch.Len() > 20 {
+		t.Errorf("expected at most 20 tracked words, got %d", sketch.Len())
+	}
+	for word, n := range exact.Counts() {
+		if est := sketch.Count(word); est < n {
+			t.Fatalf("%s: estimate %d below true count %d", word, est, n)
+		}
+	}
+
+	want := exact.TopK(5)
+	got := sketch.TopK(5)
+	for i := range want {
+		if got[i].Word != want[i].Word {
+			t.Errorf("rank %d: expected %s, got %s", i, want[i].Word, got[i].Word)
+		}
+	}
+}
+
+func TestCounterSketchMerge(t *testing.T) {
+	a := NewCounter(WithSketch(256, 4, 10))
+	b := NewCounter(WithSketch(256, 4, 10))
+	a.AddText(strings.Repeat("alpha ", 50) + strings.Repeat("beta ", 5))
+	b.AddText(strings.Repeat("beta ", 60) + strings.Repeat("gamma ", 10))
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	top := a.TopK(2)
+	if top[0].Word != "beta" || top[0].Count < 65 || top[1].Word != "alpha" {
+		t.Errorf("unexpected top words after merge: %v", top)
+	}
+	if a.Total() != 125 {
+		t.Errorf("expected total 125, got %d", a.Total())
+	}
+}
@@ -0,0 +1,143 @@
+package wordfreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Progress describes how far CountDir has got.
+type Progress struct {
+	Path       string // file just counted
+	FilesDone  int
+	BytesRead  int64
+	WordsTotal int
+}
+
+// CountDir counts the words of every regular file under root using a bounded
+// pool of workers, each building a per-file Counter that is merged into the
+// result. It stops at the first error or when ctx is cancelled. Options
+// configure both the counting pipeline and the walk (WithWorkers,
+// WithFileFilter, WithProgress).
+func CountDir(ctx context.Context, root string, opts ...Option) (*Counter, error) {
+	cfg := newConfig(opts)
+	workers := cfg.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	paths := make(chan string)
+	results := make(chan fileResult)
+	walkErr := make(chan error, 1)
+
+	go func() {
+		defer close(paths)
+		walkErr <- filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() || (cfg.fileFilter != nil && !cfg.fileFilter(path)) {
+				return nil
+			}
+			select {
+			case paths <- path:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				c := NewCounter(opts...)
+				n, err := countFile(ctx, c, path)
+				select {
+				case results <- fileResult{path: path, counter: c, bytes: n, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	total := NewCounter(opts...)
+	progress := Progress{}
+	var firstErr error
+	for r := range results {
+		if firstErr != nil {
+			continue
+		}
+		if r.err == nil {
+			r.err = total.Merge(r.counter)
+		}
+		if r.err != nil {
+			firstErr = fmt.Errorf("wordfreq: %s: %w", r.path, r.err)
+			cancel()
+			continue
+		}
+		progress.Path = r.path
+		progress.FilesDone++
+		progress.BytesRead += r.bytes
+		progress.WordsTotal = total.Total()
+		if cfg.progress != nil {
+			cfg.progress(progress)
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := <-walkErr; err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return total, nil
+}
+
+type fileResult struct {
+	path    string
+	counter *Counter
+	bytes   int64
+	err     error
+}
+
+func countFile(ctx context.Context, c *Counter, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return c.ReadFrom(ctxReader{ctx: ctx, r: f})
+}
+
+// ctxReader stops reading once its context is cancelled.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
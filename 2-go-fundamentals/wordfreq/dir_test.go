@@ -0,0 +1,106 @@
+package wordfreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTree creates files under a temporary directory and returns its path.
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestCountDir(t *testing.T) {
+	files := map[string]string{
+		"README.md":          "Go docs\nread the docs",
+		"guide/intro.md":     "The intro to Go",
+		"guide/deep/api.txt": "API docs for Go",
+		"notes.log":          "log noise noise",
+	}
+	for i := range 20 {
+		files[fmt.Sprintf("many/%02d.md", i)] = "go"
+	}
+	root := writeTree(t, files)
+
+	var all strings.Builder
+	for _, content := range files {
+		all.WriteString(content + "\n")
+	}
+
+	t.Run("all files", func(t *testing.T) {
+		c, err := CountDir(context.Background(), root, WithWorkers(4))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if expected := WordFrequencyCount(all.String()); !maps.Equal(c.Counts(), expected) {
+			t.Errorf("expected %v, got %v", expected, c.Counts())
+		}
+	})
+
+	t.Run("filter and progress", func(t *testing.T) {
+		var reports []Progress
+		c, err := CountDir(context.Background(), root,
+			WithFileFilter(func(path string) bool { return filepath.Ext(path) == ".md" }),
+			WithProgress(func(p Progress) { reports = append(reports, p) }),
+			WithStopWords("the", "to"),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Count("noise") != 0 || c.Count("api") != 0 || c.Count("the") != 0 {
+			t.Errorf("filtered files or stop words were counted: %v", c.Counts())
+		}
+		if c.Count("go") != 22 {
+			t.Errorf("expected go=22, got %d", c.Count("go"))
+		}
+		if len(reports) != 22 {
+			t.Fatalf("expected 22 progress reports, got %d", len(reports))
+		}
+		last := reports[len(reports)-1]
+		if last.FilesDone != 22 || last.WordsTotal != c.Total() || last.BytesRead == 0 {
+			t.Errorf("unexpected final progress %+v", last)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := CountDir(ctx, root); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		if _, err := CountDir(context.Background(), filepath.Join(root, "nope")); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("expected fs.ErrNotExist, got %v", err)
+		}
+	})
+
+	t.Run("sketch mode", func(t *testing.T) {
+		c, err := CountDir(context.Background(), root, WithSketch(256, 4, 5))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if top := c.TopK(1); len(top) != 1 || top[0].Word != "go" {
+			t.Errorf("expected go on top, got %v", top)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package wordfreq
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SortOrder selects how Sorted orders words.
+type SortOrder int
+
+const (
+	// ByCount lists the most frequent words first, ties alphabetically.
+	ByCount SortOrder = iota
+	// ByWord lists words alphabetically.
+	ByWord
+)
+
+// Sorted turns a frequency map into a slice with a stable, deterministic order.
+func Sorted(frequency map[string]int, order SortOrder) []WordCount {
+	out := make([]WordCount, 0, len(frequency))
+	for word, n := range frequency {
+		out = append(out, WordCount{Word: word, Count: n})
+	}
+	SortCounts(out, order)
+	return out
+}
+
+// SortCounts reorders counts in place, e.g. to list the result of TopK alphabetically.
+func SortCounts(counts []WordCount, order SortOrder) {
+	if order == ByWord {
+		slices.SortFunc(counts, func(a, b WordCount) int {
+			return strings.Compare(a.Word, b.Word)
+		})
+	} else {
+		slices.SortFunc(counts, compareWordCounts)
+	}
+}
+
+var sortOrderNames = map[string]SortOrder{
+	"count": ByCount,
+	"word":  ByWord,
+}
+
+// ParseSortOrder maps "count" or "word" to a SortOrder.
+func ParseSortOrder(name string) (SortOrder, error) {
+	order, ok := sortOrderNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("wordfreq: unknown sort order %q (want count or word)", name)
+	}
+	return order, nil
+}
+
+// SortOrderNames returns the names ParseSortOrder accepts, sorted.
+func SortOrderNames() []string {
+	return slices.Sorted(maps.Keys(sortOrderNames))
+}
+
+// Format is an output format for WriteCounts.
+type Format int
+
+const (
+	// FormatTable is an aligned plain-text table.
+	FormatTable Format = iota
+	// FormatCSV is RFC 4180 CSV with a word,count header.
+	FormatCSV
+	// FormatJSON is a JSON array of {"word", "count"} objects.
+	FormatJSON
+	// FormatMarkdown is a GitHub-flavoured Markdown table.
+	FormatMarkdown
+)
+
+var formatNames = map[string]Format{
+	"table":    FormatTable,
+	"text":     FormatTable,
+	"csv":      FormatCSV,
+	"json":     FormatJSON,
+	"markdown": FormatMarkdown,
+	"md":       FormatMarkdown,
+}
+
+// ParseFormat maps a name such as "csv" or "markdown" to a Format.
+func ParseFormat(name string) (Format, error) {
+	f, ok := formatNames[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("wordfreq: unknown format %q (want table, csv, json or markdown)", name)
+	}
+	return f, nil
+}
+
+// FormatNames returns the names ParseFormat accepts, aliases included, sorted.
+func FormatNames() []string {
+	return slices.Sorted(maps.Keys(formatNames))
+}
+
+// WriteCounts writes counts to w in the given format, in the order given.
+func WriteCounts(w io.Writer, counts []WordCount, format Format) error {
+	switch format {
+	case FormatTable:
+		wordWidth, countWidth := len("WORD"), len("COUNT")
+		for _, wc := range counts {
+			wordWidth = max(wordWidth, utf8.RuneCountInString(wc.Word))
+			countWidth = max(countWidth, len(strconv.Itoa(wc.Count)))
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%-*s  %*s\n", wordWidth, "WORD", countWidth, "COUNT")
+		for _, wc := range counts {
+			fmt.Fprintf(&sb, "%-*s  %*d\n", wordWidth, wc.Word, countWidth, wc.Count)
+		}
+		_, err := io.WriteString(w, sb.String())
+		return err
+
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"word", "count"})
+		for _, wc := range counts {
+			cw.Write([]string{wc.Word, strconv.Itoa(wc.Count)})
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case FormatJSON:
+		if counts == nil {
+			counts = []WordCount{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(counts)
+
+	case FormatMarkdown:
+		var sb strings.Builder
+		sb.WriteString("| Word | Count |\n| --- | ---: |\n")
+		for _, wc := range counts {
+			fmt.Fprintf(&sb, "| %s | %d |\n", escapeMarkdown(wc.Word), wc.Count)
+		}
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+	return fmt.Errorf("wordfreq: unknown format %d", format)
+}
+
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`).Replace(s)
+}
@@ -0,0 +1,96 @@
+package wordfreq
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSorted(t *testing.T) {
+	frequency := map[string]int{"b": 2, "a": 2, "c": 5, "d": 1}
+
+	byCount := []WordCount{{"c", 5}, {"a", 2}, {"b", 2}, {"d", 1}}
+	if got := Sorted(frequency, ByCount); !slices.Equal(got, byCount) {
+		t.Errorf("expected %v, got %v", byCount, got)
+	}
+	byWord := []WordCount{{"a", 2}, {"b", 2}, {"c", 5}, {"d", 1}}
+	if got := Sorted(frequency, ByWord); !slices.Equal(got, byWord) {
+		t.Errorf("expected %v, got %v", byWord, got)
+	}
+}
+
+func TestWriteCounts(t *testing.T) {
+	counts := []WordCount{{"go", 12}, {"ሰላም", 3}, {"a|b", 1}}
+	tests := []struct {
+		name     string
+		format   Format
+		expected string
+	}{
+		{"table", FormatTable, "WORD  COUNT\ngo       12\nሰላም       3\na|b       1\n"},
+		{"csv", FormatCSV, "word,count\ngo,12\nሰላም,3\na|b,1\n"},
+		{"markdown", FormatMarkdown, "| Word | Count |\n| --- | ---: |\n| go | 12 |\n| ሰላም | 3 |\n| a\\|b | 1 |\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := WriteCounts(&sb, counts, tt.format); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sb.String() != tt.expected {
+				t.Errorf("expected\n%s\ngot\n%s", tt.expected, sb.String())
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var sb strings.Builder
+		if err := WriteCounts(&sb, counts, FormatJSON); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var decoded []WordCount
+		if err := json.Unmarshal([]byte(sb.String()), &decoded); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if !slices.Equal(decoded, counts) {
+			t.Errorf("expected %v, got %v", counts, decoded)
+		}
+
+		sb.Reset()
+		WriteCounts(&sb, nil, FormatJSON)
+		if strings.TrimSpace(sb.String()) != "[]" {
+			t.Errorf("expected empty array, got %q", sb.String())
+		}
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, expected := range map[string]Format{"table": FormatTable, "CSV": FormatCSV, " json ": FormatJSON, "md": FormatMarkdown} {
+		if got, err := ParseFormat(name); err != nil || got != expected {
+			t.Errorf("ParseFormat(%q): expected %v, got %v (%v)", name, expected, got, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	for _, name := range FormatNames() {
+		if _, err := ParseFormat(name); err != nil {
+			t.Errorf("ParseFormat(%q): %v", name, err)
+		}
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	for name, expected := range map[string]SortOrder{"count": ByCount, "Word": ByWord, " word ": ByWord} {
+		if got, err := ParseSortOrder(name); err != nil || got != expected {
+			t.Errorf("ParseSortOrder(%q): expected %v, got %v (%v)", name, expected, got, err)
+		}
+	}
+	if _, err := ParseSortOrder("length"); err == nil {
+		t.Error("expected error for unknown sort order")
+	}
+	if expected := []string{"count", "word"}; !slices.Equal(SortOrderNames(), expected) {
+		t.Errorf("expected %q, got %q", expected, SortOrderNames())
+	}
+}
@@ -0,0 +1,132 @@
+package wordfreq
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// NGramCount returns a map of n-gram -> frequency, where an n-gram is n
+// consecutive words joined by a single space. Words pass through the same
+// pipeline as WordFrequencyCount, so stop words removed by opts are skipped
+// over rather than breaking the sequence. n must be at least 1.
+func NGramCount(text string, n int, opts ...Option) map[string]int {
+	frequency := make(map[string]int)
+	if n < 1 {
+		return frequency
+	}
+	words := newConfig(opts).words(text)
+	for i := 0; i+n <= len(words); i++ {
+		frequency[strings.Join(words[i:i+n], " ")]++
+	}
+	return frequency
+}
+
+// NGramCountReader is NGramCount for a stream. It reads r one line at a time,
+// carrying the last n-1 words over to the next line, so n-grams that span a
+// line break are counted and only the current line is held in memory.
+func NGramCountReader(r io.Reader, n int, opts ...Option) (map[string]int, error) {
+	frequency := make(map[string]int)
+	if n < 1 {
+		return frequency, nil
+	}
+	cfg := newConfig(opts)
+	br := bufio.NewReader(r)
+	var carry []string
+	for {
+		line, err := br.ReadString('\n')
+		words := append(carry, cfg.words(line)...)
+		for i := 0; i+n <= len(words); i++ {
+			frequency[strings.Join(words[i:i+n], " ")]++
+		}
+		carry = slices.Clone(words[max(len(words)-(n-1), 0):])
+		if err == io.EOF {
+			return frequency, nil
+		}
+		if err != nil {
+			return frequency, err
+		}
+	}
+}
+
+// Collocation scores how strongly two adjacent words are associated.
+type Collocation struct {
+	First  string `json:"first"`
+	Second string `json:"second"`
+	Count  int    `json:"count"`
+	// PMI is the pointwise mutual information in bits: how much more often the
+	// pair occurs than if the words were independent. It favours rare pairs.
+	PMI float64 `json:"pmi"`
+	// LogLikelihood is Dunning's G² statistic for the pair, which stays
+	// reliable for low counts and is used to rank results.
+	LogLikelihood float64 `json:"log_likelihood"`
+}
+
+// Collocations returns the bigrams of text seen at least minCount times, scored
+// with PMI and log-likelihood and sorted by log-likelihood, strongest first.
+func Collocations(text string, minCount int, opts ...Option) []Collocation {
+	words := newConfig(opts).words(text)
+	if len(words) < 2 {
+		return nil
+	}
+
+	type pair struct{ first, second string }
+	pairs := make(map[pair]int)
+	firsts := make(map[string]int)
+	seconds := make(map[string]int)
+	for i := 0; i+1 < len(words); i++ {
+		pairs[pair{words[i], words[i+1]}]++
+		firsts[words[i]]++
+		seconds[words[i+1]]++
+	}
+	n := float64(len(words) - 1)
+
+	var out []Collocation
+	for p, count := range pairs {
+		if count < minCount {
+			continue
+		}
+		k11 := float64(count)
+		k12 := float64(firsts[p.first]) - k11
+		k21 := float64(seconds[p.second]) - k11
+		k22 := n - k11 - k12 - k21
+
+		out = append(out, Collocation{
+			First:         p.first,
+			Second:        p.second,
+			Count:         count,
+			PMI:           math.Log2(k11 * n / (float64(firsts[p.first]) * float64(seconds[p.second]))),
+			LogLikelihood: logLikelihood(k11, k12, k21, k22),
+		})
+	}
+
+	slices.SortFunc(out, func(a, b Collocation) int {
+		if c := cmp.Compare(b.LogLikelihood, a.LogLikelihood); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.First, b.First); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Second, b.Second)
+	})
+	return out
+}
+
+// logLikelihood computes G² = 2 Σ O ln(O/E) for a 2x2 contingency table.
+func logLikelihood(k11, k12, k21, k22 float64) float64 {
+	n := k11 + k12 + k21 + k22
+	row1, row2 := k11+k12, k21+k22
+	col1, col2 := k11+k21, k12+k22
+
+	term := func(observed, rowTotal, colTotal float64) float64 {
+		if observed == 0 {
+			return 0
+		}
+		return observed * math.Log(observed*n/(rowTotal*colTotal))
+	}
+	g2 := 2 * (term(k11, row1, col1) + term(k12, row1, col2) + term(k21, row2, col1) + term(k22, row2, col2))
+	return max(g2, 0)
+}
@@ -0,0 +1,92 @@
+package wordfreq
+
+import (
+	"maps"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestNGramCount(t *testing.T) {
+	text := "to be or not to be"
+	tests := []struct {
+		name     string
+		n        int
+		opts     []Option
+		expected map[string]int
+	}{
+		{"unigrams", 1, nil, map[string]int{"to": 2, "be": 2, "or": 1, "not": 1}},
+		{"bigrams", 2, nil, map[string]int{"to be": 2, "be or": 1, "or not": 1, "not to": 1}},
+		{"trigrams", 3, nil, map[string]int{"to be or": 1, "be or not": 1, "or not to": 1, "not to be": 1}},
+		{"too long", 7, nil, map[string]int{}},
+		{"invalid n", 0, nil, map[string]int{}},
+		{"stop words skipped", 2, []Option{WithStopWords("or", "not")}, map[string]int{"to be": 2, "be to": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NGramCount(text, tt.n, tt.opts...)
+			if !maps.Equal(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNGramCountReader(t *testing.T) {
+	text := "to be\nor not\n\nto be, that is\nthe question"
+	for n := 0; n <= 4; n++ {
+		result, err := NGramCountReader(strings.NewReader(text), n, WithStopWords("that"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := NGramCount(text, n, WithStopWords("that")); !maps.Equal(result, expected) {
+			t.Errorf("n=%d: expected %v, got %v", n, expected, result)
+		}
+	}
+}
+
+func TestCollocations(t *testing.T) {
+	text := strings.Repeat("new york is big and new york is busy ", 5) +
+		"the cat sat on the mat and the dog sat on the rug"
+	result := Collocations(text, 3)
+	if len(result) == 0 {
+		t.Fatal("expected collocations")
+	}
+
+	top := result[0]
+	if top.First != "new" || top.Second != "york" || top.Count != 10 {
+		t.Errorf("expected \"new york\" x10 first, got %+v", top)
+	}
+	for _, c := range result {
+		if c.Count < 3 {
+			t.Errorf("pair below minCount returned: %+v", c)
+		}
+	}
+	for i := 1; i < len(result); i++ {
+		if result[i].LogLikelihood > result[i-1].LogLikelihood {
+			t.Fatalf("results not sorted by log-likelihood: %+v", result)
+		}
+	}
+
+	if got := Collocations("single", 1); got != nil {
+		t.Errorf("expected nil for one word, got %v", got)
+	}
+}
+
+func TestCollocationScores(t *testing.T) {
+	// "a b" is the only bigram, so each word always appears with the other.
+	result := Collocations("a b", 1)
+	if len(result) != 1 || result[0].PMI != 0 {
+		t.Fatalf("expected PMI 0 for a single bigram, got %+v", result)
+	}
+
+	// Reference value computed independently from 2 Σ O ln(O/E).
+	got := logLikelihood(10, 20, 30, 940)
+	if math.Abs(got-30.069075) > 1e-6 {
+		t.Errorf("expected G² ≈ 30.069075, got %v", got)
+	}
+	if logLikelihood(5, 0, 0, 5) <= 0 {
+		t.Error("expected a positive score for a perfectly associated pair")
+	}
+}
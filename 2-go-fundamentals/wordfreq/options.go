@@ -0,0 +1,171 @@
+package wordfreq
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+)
+
+// CaseMode controls how words are case-normalized before counting.
+type CaseMode int
+
+const (
+	// CaseLower lowercases words with strings.ToLower. This is the default.
+	CaseLower CaseMode = iota
+	// CaseFold applies full Unicode case folding, so "Straße" and "STRASSE" match.
+	CaseFold
+	// CasePreserve leaves words as the tokenizer produced them.
+	CasePreserve
+)
+
+type config struct {
+	tokenizer      Tokenizer
+	caseMode       CaseMode
+	stopWords      map[string]struct{}
+	stemmer        Stemmer
+	minLength      int
+	sketchWidth    int
+	sketchDepth    int
+	sketchCapacity int
+	workers        int
+	fileFilter     func(path string) bool
+	progress       func(Progress)
+
+	// folder is the case folder for CaseFold. A Caser keeps state, so a
+	// config must not be shared between goroutines; each Counter has its own.
+	folder cases.Caser
+}
+
+// Option configures how text is turned into counts.
+type Option func(*config)
+
+func newConfig(opts []Option) *config {
+	cfg := &config{tokenizer: UnicodeTokenizer{}}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.caseMode == CaseFold {
+		cfg.folder = cases.Fold()
+	}
+	if cfg.caseMode != CasePreserve && len(cfg.stopWords) > 0 {
+		stopWords := make(map[string]struct{}, len(cfg.stopWords))
+		for w := range cfg.stopWords {
+			stopWords[cfg.normalizeCase(w)] = struct{}{}
+		}
+		cfg.stopWords = stopWords
+	}
+	return cfg
+}
+
+// WithTokenizer replaces the default UnicodeTokenizer.
+func WithTokenizer(t Tokenizer) Option {
+	return func(c *config) {
+		c.tokenizer = t
+	}
+}
+
+// WithCaseMode selects how words are case-normalized.
+func WithCaseMode(mode CaseMode) Option {
+	return func(c *config) {
+		c.caseMode = mode
+	}
+}
+
+// WithStopWords drops the given words. They are compared after case
+// normalization and before stemming. Repeated use adds to the list.
+func WithStopWords(words ...string) Option {
+	return func(c *config) {
+		if c.stopWords == nil {
+			c.stopWords = make(map[string]struct{}, len(words))
+		}
+		for _, w := range words {
+			c.stopWords[w] = struct{}{}
+		}
+	}
+}
+
+// WithStemmer reduces every word to its stem, e.g. with PorterStemmer.
+func WithStemmer(s Stemmer) Option {
+	return func(c *config) {
+		c.stemmer = s
+	}
+}
+
+// WithMinLength drops words shorter than n characters (runes), measured
+// before stemming.
+func WithMinLength(n int) Option {
+	return func(c *config) {
+		c.minLength = n
+	}
+}
+
+// WithSketch switches a Counter to approximate mode: counts are kept in a
+// count-min sketch of width x depth cells and only the capacity most frequent
+// words are remembered for TopK. Memory stays fixed regardless of vocabulary size.
+// Counts are never underestimated and exceed the true count by at most
+// e*Total()/width with probability 1 - e^-depth.
+func WithSketch(width, depth, capacity int) Option {
+	return func(c *config) {
+		c.sketchWidth = width
+		c.sketchDepth = depth
+		c.sketchCapacity = capacity
+	}
+}
+
+// WithWorkers sets how many files CountDir reads concurrently. The default is GOMAXPROCS.
+func WithWorkers(n int) Option {
+	return func(c *config) {
+		c.workers = n
+	}
+}
+
+// WithFileFilter restricts CountDir to regular files for which keep returns true.
+func WithFileFilter(keep func(path string) bool) Option {
+	return func(c *config) {
+		c.fileFilter = keep
+	}
+}
+
+// WithProgress makes CountDir call report after each file is counted.
+// Calls are made from a single goroutine, never concurrently.
+func WithProgress(report func(Progress)) Option {
+	return func(c *config) {
+		c.progress = report
+	}
+}
+
+// words runs text through the processing pipeline: tokenize, normalize case,
+// drop stop words and short words, then stem.
+func (c *config) words(text string) []string {
+	tokens := c.tokenizer.Tokenize(text)
+
+	// Build a new slice: a custom Tokenizer may reuse the one it returned.
+	words := make([]string, 0, len(tokens))
+	for _, w := range tokens {
+		w = c.normalizeCase(w)
+		if _, stop := c.stopWords[w]; stop {
+			continue
+		}
+		if c.minLength > 0 && utf8.RuneCountInString(w) < c.minLength {
+			continue
+		}
+		if c.stemmer != nil {
+			w = c.stemmer.Stem(w)
+		}
+		words = append(words, w)
+	}
+	return words
+}
+
+func (c *config) normalizeCase(w string) string {
+	switch c.caseMode {
+	case CaseFold:
+		return c.folder.String(w)
+	case CasePreserve:
+		return w
+	default:
+		return strings.ToLower(w)
+	}
+}
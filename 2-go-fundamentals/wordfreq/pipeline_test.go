@@ -0,0 +1,114 @@
+package wordfreq
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPorterStemmer(t *testing.T) {
+	// Pairs taken from Porter's published vocabulary and output lists.
+	pairs := map[string]string{
+		"caresses": "caress", "ponies": "poni", "ties": "ti", "caress": "caress",
+		"cats": "cat", "feed": "feed", "agreed": "agre", "plastered": "plaster",
+		"bled": "bled", "motoring": "motor", "sing": "sing", "conflated": "conflat",
+		"troubled": "troubl", "sized": "size", "hopping": "hop", "tanned": "tan",
+		"falling": "fall", "hissing": "hiss", "fizzed": "fizz", "failing": "fail",
+		"filing": "file", "happy": "happi", "sky": "sky", "relational": "relat",
+		"conditional": "condit", "rational": "ration", "valenci": "valenc",
+		"digitizer": "digit", "conformabli": "conform", "radicalli": "radic",
+		"differentli": "differ", "vileli": "vile", "analogousli": "analog",
+		"vietnamization": "vietnam", "predication": "predic", "operator": "oper",
+		"feudalism": "feudal", "decisiveness": "decis", "hopefulness": "hope",
+		"callousness": "callous", "formaliti": "formal", "sensitiviti": "sensit",
+		"sensibiliti": "sensibl", "triplicate": "triplic", "formative": "form",
+		"formalize": "formal", "electriciti": "electr", "electrical": "electr",
+		"hopeful": "hope", "goodness": "good", "revival": "reviv",
+		"allowance": "allow", "inference": "infer", "airliner": "airlin",
+		"gyroscopic": "gyroscop", "adjustable": "adjust", "defensible": "defens",
+		"irritant": "irrit", "replacement": "replac", "adjustment": "adjust",
+		"dependent": "depend", "adoption": "adopt", "homologou": "homolog",
+		"communism": "commun", "activate": "activ", "angulariti": "angular",
+		"homologous": "homolog", "effective": "effect", "bowdlerize": "bowdler",
+		"probate": "probat", "rate": "rate", "cease": "ceas", "controll": "control",
+		"roll": "roll", "generalizations": "gener", "oscillators": "oscil",
+		"is": "is", "café": "café",
+	}
+
+	for word, expected := range pairs {
+		if got := (PorterStemmer{}).Stem(word); got != expected {
+			t.Errorf("Stem(%q): expected %q, got %q", word, expected, got)
+		}
+	}
+}
+
+func TestPipelineOptions(t *testing.T) {
+	text := "The runner was running and the runners ran; a Straße and STRASSE"
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected map[string]int
+	}{
+		{
+			"stop words and stemming",
+			[]Option{WithStopWords(EnglishStopWords...), WithStemmer(PorterStemmer{})},
+			map[string]int{"runner": 2, "run": 1, "ran": 1, "straße": 1, "strass": 1},
+		},
+		{
+			"case folding",
+			[]Option{WithCaseMode(CaseFold), WithStopWords(EnglishStopWords...)},
+			map[string]int{"runner": 1, "running": 1, "runners": 1, "ran": 1, "strasse": 2},
+		},
+		{
+			"preserve case with min length",
+			[]Option{WithCaseMode(CasePreserve), WithMinLength(4), WithStopWords("runner")},
+			map[string]int{"running": 1, "runners": 1, "Straße": 1, "STRASSE": 1},
+		},
+		{
+			"stop words are case-normalized",
+			[]Option{WithStopWords("THE", "And", "A", "Was")},
+			map[string]int{"runner": 1, "running": 1, "runners": 1, "ran": 1, "straße": 1, "strasse": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WordFrequencyCount(text, tt.opts...)
+			if !maps.Equal(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLoadStopWordsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stop.txt")
+	content := "# custom list\nfoo bar\n\n  baz  \n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	words, err := LoadStopWordsFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"foo", "bar", "baz"}; !slices.Equal(words, expected) {
+		t.Errorf("expected %v, got %v", expected, words)
+	}
+
+	c := NewCounter(WithStopWords(words...))
+	c.AddText("foo qux BAR baz qux")
+	if !maps.Equal(c.Counts(), map[string]int{"qux": 2}) {
+		t.Errorf("unexpected counts %v", c.Counts())
+	}
+
+	if _, err := LoadStopWordsFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := LoadStopWords(strings.NewReader("")); err != nil {
+		t.Errorf("unexpected error for empty list: %v", err)
+	}
+}
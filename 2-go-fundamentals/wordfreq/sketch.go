@@ -0,0 +1,123 @@
+package wordfreq
+
+import (
+	"container/heap"
+	"hash/fnv"
+)
+
+// countMinSketch estimates frequencies in fixed memory. Hashing is
+// deterministic so sketches built in different processes can be merged.
+type countMinSketch struct {
+	width int
+	depth int
+	cells []int // depth rows of width cells
+}
+
+func newCountMinSketch(width, depth int) *countMinSketch {
+	return &countMinSketch{
+		width: width,
+		depth: depth,
+		cells: make([]int, width*depth),
+	}
+}
+
+// indexes calls fn with the cell index of word in every row, using double
+// hashing to derive depth hash functions from a single 64-bit hash.
+func (s *countMinSketch) indexes(word string, fn func(i int)) {
+	h := fnv.New64a()
+	h.Write([]byte(word))
+	sum := h.Sum64()
+	h1, h2 := uint32(sum), uint32(sum>>32)|1
+
+	for row := 0; row < s.depth; row++ {
+		col := (uint64(h1) + uint64(row)*uint64(h2)) % uint64(s.width)
+		fn(row*s.width + int(col))
+	}
+}
+
+// add records n occurrences of word and returns its new estimate.
+func (s *countMinSketch) add(word string, n int) int {
+	est := -1
+	s.indexes(word, func(i int) {
+		s.cells[i] += n
+		if est < 0 || s.cells[i] < est {
+			est = s.cells[i]
+		}
+	})
+	return est
+}
+
+func (s *countMinSketch) estimate(word string) int {
+	est := -1
+	s.indexes(word, func(i int) {
+		if est < 0 || s.cells[i] < est {
+			est = s.cells[i]
+		}
+	})
+	return max(est, 0)
+}
+
+func (s *countMinSketch) merge(other *countMinSketch) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatible
+	}
+	for i, n := range other.cells {
+		s.cells[i] += n
+	}
+	return nil
+}
+
+// topTracker remembers the capacity words with the highest estimated counts.
+// It is an indexed min-heap so the weakest candidate can be evicted in O(log n).
+type topTracker struct {
+	capacity int
+	entries  []WordCount
+	index    map[string]int // word -> position in entries
+}
+
+func newTopTracker(capacity int) *topTracker {
+	return &topTracker{
+		capacity: capacity,
+		index:    make(map[string]int, capacity),
+	}
+}
+
+// offer updates word's count if it is tracked, or admits it if it outranks the
+// weakest tracked word.
+func (t *topTracker) offer(word string, count int) {
+	wc := WordCount{Word: word, Count: count}
+	if i, ok := t.index[word]; ok {
+		t.entries[i].Count = count
+		heap.Fix(t, i)
+		return
+	}
+	if len(t.entries) < t.capacity {
+		heap.Push(t, wc)
+		return
+	}
+	if ranksBefore(wc, t.entries[0]) {
+		delete(t.index, t.entries[0].Word)
+		t.entries[0] = wc
+		t.index[word] = 0
+		heap.Fix(t, 0)
+	}
+}
+
+func (t *topTracker) Len() int           { return len(t.entries) }
+func (t *topTracker) Less(i, j int) bool { return ranksBefore(t.entries[j], t.entries[i]) }
+func (t *topTracker) Swap(i, j int) {
+	t.entries[i], t.entries[j] = t.entries[j], t.entries[i]
+	t.index[t.entries[i].Word] = i
+	t.index[t.entries[j].Word] = j
+}
+func (t *topTracker) Push(x any) {
+	wc := x.(WordCount)
+	t.index[wc.Word] = len(t.entries)
+	t.entries = append(t.entries, wc)
+}
+func (t *topTracker) Pop() any {
+	last := t.entries[len(t.entries)-1]
+	t.entries = t.entries[:len(t.entries)-1]
+	delete(t.index, last.Word)
+	return last
+}
@@ -0,0 +1,262 @@
+package wordfreq
+
+// Stemmer reduces a word to its stem so inflected forms are counted together.
+type Stemmer interface {
+	Stem(word string) string
+}
+
+// PorterStemmer implements Martin Porter's 1980 suffix-stripping algorithm for
+// English. It expects lowercase input; words containing anything other than
+// ASCII letters, and words of two letters or fewer, are returned unchanged.
+type PorterStemmer struct{}
+
+// Stem implements Stemmer.
+func (PorterStemmer) Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	p := &porter{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// porter holds the word being stemmed. b[0..k] is the current word and
+// b[0..j] the stem left after a successful call to ends.
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+// cons reports whether b[i] is a consonant. 'y' is a consonant unless it follows one.
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m measures the number of vowel-consonant sequences in b[0..j]:
+// [C](VC)^m[V].
+func (p *porter) m() int {
+	n, i := 0, 0
+	for ; i <= p.j && p.cons(i); i++ {
+	}
+	for {
+		for ; i <= p.j && !p.cons(i); i++ {
+		}
+		if i > p.j {
+			return n
+		}
+		for ; i <= p.j && p.cons(i); i++ {
+		}
+		n++
+		if i > p.j {
+			return n
+		}
+	}
+}
+
+// vowelInStem reports whether b[0..j] contains a vowel.
+func (p *porter) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleC reports whether b[i-1..i] is a double consonant.
+func (p *porter) doubleC(i int) bool {
+	return i >= 1 && p.b[i] == p.b[i-1] && p.cons(i)
+}
+
+// cvc reports whether b[i-2..i] is consonant-vowel-consonant and the final
+// consonant is not w, x or y, as in "hop" but not "snow".
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+// ends reports whether b[0..k] ends with s, setting j to the end of the stem.
+func (p *porter) ends(s string) bool {
+	n := len(s)
+	if n > p.k+1 || string(p.b[p.k-n+1:p.k+1]) != s {
+		return false
+	}
+	p.j = p.k - n
+	return true
+}
+
+// setTo replaces b[j+1..k] with s.
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+// replace calls setTo(s) if the stem has a measure greater than zero.
+func (p *porter) replace(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+// step1ab removes plurals and -ed or -ing.
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.k -= 2
+		case p.ends("ies"):
+			p.setTo("i")
+		case p.b[p.k-1] != 's':
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+		return
+	}
+	if (p.ends("ed") || p.ends("ing")) && p.vowelInStem() {
+		p.k = p.j
+		switch {
+		case p.ends("at"):
+			p.setTo("ate")
+		case p.ends("bl"):
+			p.setTo("ble")
+		case p.ends("iz"):
+			p.setTo("ize")
+		case p.doubleC(p.k):
+			switch p.b[p.k] {
+			case 'l', 's', 'z':
+			default:
+				p.k--
+			}
+		default:
+			p.j = p.k
+			if p.m() == 1 && p.cvc(p.k) {
+				p.setTo("e")
+			}
+		}
+	}
+}
+
+// step1c turns a terminal y into i when there is another vowel in the stem.
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// step2 maps double suffixes to single ones, e.g. -ization to -ize.
+func (p *porter) step2() {
+	p.replaceFirst(step2Rules[p.b[p.k-1]])
+}
+
+// step3 handles -ic-, -full, -ness and similar suffixes.
+func (p *porter) step3() {
+	p.replaceFirst(step3Rules[p.b[p.k]])
+}
+
+type suffixRule struct {
+	suffix, replacement string
+}
+
+var step2Rules = map[byte][]suffixRule{
+	'a': {{"ational", "ate"}, {"tional", "tion"}},
+	'c': {{"enci", "ence"}, {"anci", "ance"}},
+	'e': {{"izer", "ize"}},
+	'l': {{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"}},
+	'o': {{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}},
+	's': {{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"}},
+	't': {{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"}},
+	'g': {{"logi", "log"}},
+}
+
+var step3Rules = map[byte][]suffixRule{
+	'e': {{"icate", "ic"}, {"ative", ""}, {"alize", "al"}},
+	'i': {{"iciti", "ic"}},
+	'l': {{"ical", "ic"}, {"ful", ""}},
+	's': {{"ness", ""}},
+}
+
+// replaceFirst applies the first rule whose suffix matches.
+func (p *porter) replaceFirst(rules []suffixRule) {
+	for _, r := range rules {
+		if p.ends(r.suffix) {
+			p.replace(r.replacement)
+			return
+		}
+	}
+}
+
+var step4Suffixes = map[byte][]string{
+	'a': {"al"},
+	'c': {"ance", "ence"},
+	'e': {"er"},
+	'i': {"ic"},
+	'l': {"able", "ible"},
+	'n': {"ant", "ement", "ment", "ent"},
+	'o': {"ion", "ou"},
+	's': {"ism"},
+	't': {"ate", "iti"},
+	'u': {"ous"},
+	'v': {"ive"},
+	'z': {"ize"},
+}
+
+// step4 removes -ant, -ence and similar suffixes when the stem has m > 1.
+func (p *porter) step4() {
+	for _, suffix := range step4Suffixes[p.b[p.k-1]] {
+		if !p.ends(suffix) {
+			continue
+		}
+		// -ion is only removed after s or t.
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			continue
+		}
+		if p.m() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+// step5 removes a final -e when m > 1 and reduces -ll to -l when m > 1.
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		a := p.m()
+		if a > 1 || (a == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doubleC(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
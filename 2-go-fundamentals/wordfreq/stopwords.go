@@ -0,0 +1,53 @@
+package wordfreq
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// EnglishStopWords is a list of common English function words.
+var EnglishStopWords = []string{
+	"a", "about", "above", "after", "again", "against", "all", "am", "an", "and",
+	"any", "are", "as", "at", "be", "because", "been", "before", "being", "below",
+	"between", "both", "but", "by", "can", "did", "do", "does", "doing", "down",
+	"during", "each", "few", "for", "from", "further", "had", "has", "have",
+	"having", "he", "her", "here", "hers", "herself", "him", "himself", "his",
+	"how", "i", "if", "in", "into", "is", "it", "it's", "its", "itself", "just",
+	"me", "more", "most", "my", "myself", "no", "nor", "not", "now", "of", "off",
+	"on", "once", "only", "or", "other", "our", "ours", "ourselves", "out", "over",
+	"own", "same", "she", "should", "so", "some", "such", "than", "that", "the",
+	"their", "theirs", "them", "themselves", "then", "there", "these", "they",
+	"this", "those", "through", "to", "too", "under", "until", "up", "very", "was",
+	"we", "were", "what", "when", "where", "which", "while", "who", "whom", "why",
+	"will", "with", "you", "your", "yours", "yourself", "yourselves",
+}
+
+// LoadStopWords reads a stop-word list with one or more words per line.
+// Blank lines and lines starting with '#' are ignored.
+func LoadStopWords(r io.Reader) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// LoadStopWordsFile reads a stop-word list from the named file. See LoadStopWords.
+func LoadStopWordsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadStopWords(f)
+}
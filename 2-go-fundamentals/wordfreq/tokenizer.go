@@ -0,0 +1,135 @@
+package wordfreq
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenizer splits text into words.
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// TokenizerFunc adapts an ordinary function to the Tokenizer interface.
+type TokenizerFunc func(text string) []string
+
+// Tokenize calls f(text).
+func (f TokenizerFunc) Tokenize(text string) []string {
+	return f(text)
+}
+
+// UnicodeTokenizer is the default Tokenizer. It follows the spirit of Unicode
+// word segmentation (UAX #29) using the standard unicode tables:
+//
+//   - a word is a run of letters, combining marks, numbers and connector
+//     punctuation in any script, so Amharic, accented and Cyrillic words stay whole;
+//   - an apostrophe between two letters is part of the word ("don't", "l'eau"),
+//     and the typographic apostrophe ’ is normalized to ';
+//   - a hyphen between two word characters joins them ("well-known");
+//   - a '.' or ',' between two digits is part of a number ("3.14", "1,000");
+//   - Han ideographs, which are written without spaces, are emitted one
+//     character per word;
+//   - a run of Hiragana or of Katakana, including the prolonged sound mark ー,
+//     is one word, so kana words and okurigana stay together ("食べる" is
+//     "食" and "べる"). Japanese has no spaces, so this approximates words
+//     rather than segmenting them with a dictionary.
+//
+// Everything else, including script-specific punctuation such as the Ethiopic
+// wordspace ፡ and full stop ።, separates words. Case is left unchanged.
+type UnicodeTokenizer struct{}
+
+// Tokenize implements Tokenizer.
+func (UnicodeTokenizer) Tokenize(text string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	runes := []rune(text)
+	script := otherScript // script of the word being built
+	for i, r := range runes {
+		switch {
+		case isIdeograph(r):
+			flush()
+			words = append(words, string(r))
+		case isWordRune(r):
+			s := kanaScript(r)
+			if r == prolongedSoundMark && word.Len() > 0 {
+				s = script
+			}
+			if s != script {
+				flush()
+			}
+			script = s
+			word.WriteRune(r)
+		case word.Len() > 0 && i+1 < len(runes) && joinsWords(runes[i-1], r, runes[i+1]):
+			word.WriteRune(normalizeJoiner(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return words
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r) || unicode.Is(unicode.Pc, r)
+}
+
+func isIdeograph(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// Kana scripts, which are kept apart from each other and from other words.
+const (
+	otherScript = iota
+	hiragana
+	katakana
+)
+
+// prolongedSoundMark lengthens the preceding kana. It belongs to no script.
+const prolongedSoundMark = 'ー'
+
+func kanaScript(r rune) int {
+	switch {
+	case unicode.Is(unicode.Hiragana, r):
+		return hiragana
+	case unicode.Is(unicode.Katakana, r), r == prolongedSoundMark:
+		return katakana
+	}
+	return otherScript
+}
+
+// joinsWords reports whether r, found between prev and next, belongs inside a word.
+func joinsWords(prev, r, next rune) bool {
+	if isIdeograph(next) || kanaScript(next) != kanaScript(prev) {
+		return false
+	}
+	switch r {
+	case '\'', '’':
+		return isLetterOrMark(prev) && isLetterOrMark(next)
+	case '-', '‐', '‑':
+		return isWordRune(prev) && isWordRune(next)
+	case '.', ',':
+		return unicode.IsDigit(prev) && unicode.IsDigit(next)
+	}
+	return false
+}
+
+func isLetterOrMark(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+func normalizeJoiner(r rune) rune {
+	switch r {
+	case '’':
+		return '\''
+	case '‐', '‑':
+		return '-'
+	}
+	return r
+}
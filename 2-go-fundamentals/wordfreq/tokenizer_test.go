@@ -0,0 +1,81 @@
+package wordfreq
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestUnicodeTokenizer(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"english", "Hello, world! It's well-known.", []string{"Hello", "world", "It's", "well-known"}},
+		{"typographic apostrophe", "don’t stop", []string{"don't", "stop"}},
+		{"edge apostrophes and hyphens", "'quoted' -dash- rock'n'roll", []string{"quoted", "dash", "rock'n'roll"}},
+		{"numbers", "pi is 3.14, not 1,000.", []string{"pi", "is", "3.14", "not", "1,000"}},
+		{"amharic", "ሰላም፡ዓለም። ሰላም!", []string{"ሰላም", "ዓለም", "ሰላም"}},
+		{"accented latin", "Ésope reste ici, café crème", []string{"Ésope", "reste", "ici", "café", "crème"}},
+		{"combining marks", "cafe\u0301 noe\u0308l", []string{"cafe\u0301", "noe\u0308l"}},
+		{"cyrillic", "Привет, мир", []string{"Привет", "мир"}},
+		{"greek", "Καλημέρα κόσμε;", []string{"Καλημέρα", "κόσμε"}},
+		{"devanagari", "नमस्ते दुनिया।", []string{"नमस्ते", "दुनिया"}},
+		{"chinese", "我爱Go语言", []string{"我", "爱", "Go", "语", "言"}},
+		{"japanese", "カタカナとひらがな", []string{"カタカナ", "とひらがな"}},
+		{"okurigana", "私は食べる。", []string{"私", "は", "食", "べる"}},
+		{"prolonged sound mark", "コーヒーとGoのテスト", []string{"コーヒー", "と", "Go", "の", "テスト"}},
+		{"underscore", "snake_case", []string{"snake_case"}},
+		{"empty", " \t\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UnicodeTokenizer{}.Tokenize(tt.input)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWordFrequencyCountScripts(t *testing.T) {
+	result := WordFrequencyCount("ሰላም ዓለም። Hello ሰላም, HELLO Élan élan")
+	expected := map[string]int{"ሰላም": 2, "ዓለም": 1, "hello": 2, "élan": 2}
+	if !maps.Equal(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestWithTokenizer(t *testing.T) {
+	commas := TokenizerFunc(func(text string) []string {
+		return strings.Split(text, ",")
+	})
+	result := WordFrequencyCount("a b,A B,c", WithTokenizer(commas))
+	expected := map[string]int{"a b": 2, "c": 1}
+	if !maps.Equal(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	c := NewCounter(WithTokenizer(commas))
+	c.AddText("x,y,x")
+	if c.Count("x") != 2 {
+		t.Errorf("expected counter to use custom tokenizer, got %v", c.Counts())
+	}
+}
+
+func TestWithTokenizerResultNotModified(t *testing.T) {
+	cached := []string{"The", "cat", "the", "Hat"}
+	fixed := TokenizerFunc(func(string) []string { return cached })
+	c := NewCounter(WithTokenizer(fixed), WithStopWords("THE"))
+	c.AddText("")
+	c.AddText("")
+	if c.Count("cat") != 2 || c.Count("hat") != 2 || c.Count("the") != 0 {
+		t.Errorf("unexpected counts %v", c.Counts())
+	}
+	if expected := []string{"The", "cat", "the", "Hat"}; !slices.Equal(cached, expected) {
+		t.Errorf("expected tokenizer result %v to be left alone, got %v", expected, cached)
+	}
+}
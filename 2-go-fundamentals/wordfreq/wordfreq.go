@@ -1,19 +1,12 @@
 package wordfreq
 
-import (
-	"regexp"
-	"strings"
-)
-
 // WordFrequencyCount returns a map of word -> frequency from the input string.
-func WordFrequencyCount(text string) map[string]int {
-	text = strings.ToLower(text)
-	re := regexp.MustCompile(`[^\w\s]`)
-	cleanText := re.ReplaceAllString(text, "")
-	words := strings.Fields(cleanText)
-
+// By default words are found with UnicodeTokenizer and lowercased; opts add
+// stop-word removal, stemming and other normalization steps.
+func WordFrequencyCount(text string, opts ...Option) map[string]int {
+	cfg := newConfig(opts)
 	frequency := make(map[string]int)
-	for _, word := range words {
+	for _, word := range cfg.words(text) {
 		frequency[word]++
 	}
 	return frequency
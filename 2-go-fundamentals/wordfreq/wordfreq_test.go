@@ -0,0 +1,27 @@
+package wordfreq
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestWordFrequencyCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]int
+	}{
+		{"simple", "Go go GO", map[string]int{"go": 3}},
+		{"punctuation", "Hello, world! Hello?", map[string]int{"hello": 2, "world": 1}},
+		{"empty", "   ", map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WordFrequencyCount(tt.input)
+			if !maps.Equal(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -1,17 +1,76 @@
 package controllers
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"library-management/models"
 	"library-management/services"
+	"os"
+	"strconv"
+	"strings"
 )
 
-func StartConsoleApp() {
-	library := services.NewLibrary()
+// console reads whole lines from stdin so titles and names may contain spaces.
+type console struct {
+	in *bufio.Reader
+}
+
+// readLine prints prompt and returns the trimmed line entered. ok is false
+// once stdin is closed.
+func (c console) readLine(prompt string) (line string, ok bool) {
+	fmt.Print(prompt)
+	line, err := c.in.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Println()
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+// readInt prompts until a whole number is entered. ok is false once stdin is closed.
+func (c console) readInt(prompt string) (n int, ok bool) {
+	for {
+		line, ok := c.readLine(prompt)
+		if !ok {
+			return 0, false
+		}
+		n, err := strconv.Atoi(line)
+		if err == nil {
+			return n, true
+		}
+		fmt.Println("❌ Please enter a number.")
+	}
+}
 
-	// Add some sample members for testing
-	library.Members[1] = models.Member{ID: 1, Name: "Alice"}
-	library.Members[2] = models.Member{ID: 2, Name: "Bob"}
+// describe turns an error from the library into a message for library staff.
+func describe(err error) string {
+	switch {
+	case errors.Is(err, services.ErrBookNotFound):
+		return "There is no book with that ID."
+	case errors.Is(err, services.ErrBookExists):
+		return "A book with that ID already exists. Choose another ID."
+	case errors.Is(err, services.ErrAlreadyBorrowed):
+		return "That book is already borrowed."
+	case errors.Is(err, services.ErrBookOnLoan):
+		return "That book is on loan. It can be removed once it is returned."
+	case errors.Is(err, services.ErrMemberNotFound):
+		return "There is no member with that ID."
+	case errors.Is(err, services.ErrMemberExists):
+		return "A member with that ID already exists."
+	case errors.Is(err, services.ErrMemberHasBooks):
+		return "That member still has borrowed books. They must be returned first."
+	case errors.Is(err, services.ErrNotBorrowedByMember):
+		return "That member has not borrowed this book."
+	case errors.Is(err, services.ErrNameRequired):
+		return "Please enter a name."
+	default:
+		return err.Error()
+	}
+}
+
+func StartConsoleApp(library services.LibraryManager) {
+	c := console{in: bufio.NewReader(os.Stdin)}
 
 	for {
 		fmt.Println("\n===== Library Management System =====")
@@ -21,52 +80,63 @@ func StartConsoleApp() {
 		fmt.Println("4. Return Book")
 		fmt.Println("5. List Available Books")
 		fmt.Println("6. List Borrowed Books")
-		fmt.Println("7. Exit")
-		fmt.Print("Enter your choice: ")
+		fmt.Println("7. Add Member")
+		fmt.Println("8. Update Member")
+		fmt.Println("9. Remove Member")
+		fmt.Println("10. List Members")
+		fmt.Println("11. Exit")
 
-		var choice int
-		fmt.Scanln(&choice)
+		choice, ok := c.readInt("Enter your choice: ")
+		if !ok {
+			fmt.Println("👋 Exiting... Goodbye!")
+			return
+		}
 
 		switch choice {
 		case 1:
-			var id int
-			var title, author string
-			fmt.Print("Enter book ID: ")
-			fmt.Scanln(&id)
-			fmt.Print("Enter book title: ")
-			fmt.Scanln(&title)
-			fmt.Print("Enter book author: ")
-			fmt.Scanln(&author)
-			library.AddBook(models.Book{ID: id, Title: title, Author: author})
-			fmt.Println("✅ Book added successfully.")
+			id, ok1 := c.readInt("Enter book ID: ")
+			title, ok2 := c.readLine("Enter book title: ")
+			author, ok3 := c.readLine("Enter book author: ")
+			if !ok1 || !ok2 || !ok3 {
+				continue
+			}
+			if err := library.AddBook(models.Book{ID: id, Title: title, Author: author}); err != nil {
+				fmt.Println("❌", describe(err))
+			} else {
+				fmt.Println("✅ Book added successfully.")
+			}
 
 		case 2:
-			var id int
-			fmt.Print("Enter book ID to remove: ")
-			fmt.Scanln(&id)
-			library.RemoveBook(id)
-			fmt.Println("🗑️ Book removed successfully.")
+			id, ok := c.readInt("Enter book ID to remove: ")
+			if !ok {
+				continue
+			}
+			if err := library.RemoveBook(id); err != nil {
+				fmt.Println("❌", describe(err))
+			} else {
+				fmt.Println("🗑️ Book removed successfully.")
+			}
 
 		case 3:
-			var bookID, memberID int
-			fmt.Print("Enter book ID: ")
-			fmt.Scanln(&bookID)
-			fmt.Print("Enter member ID: ")
-			fmt.Scanln(&memberID)
+			bookID, ok1 := c.readInt("Enter book ID: ")
+			memberID, ok2 := c.readInt("Enter member ID: ")
+			if !ok1 || !ok2 {
+				continue
+			}
 			if err := library.BorrowBook(bookID, memberID); err != nil {
-				fmt.Println("❌", err)
+				fmt.Println("❌", describe(err))
 			} else {
 				fmt.Println("📚 Book borrowed successfully.")
 			}
 
 		case 4:
-			var bookID, memberID int
-			fmt.Print("Enter book ID: ")
-			fmt.Scanln(&bookID)
-			fmt.Print("Enter member ID: ")
-			fmt.Scanln(&memberID)
+			bookID, ok1 := c.readInt("Enter book ID: ")
+			memberID, ok2 := c.readInt("Enter member ID: ")
+			if !ok1 || !ok2 {
+				continue
+			}
 			if err := library.ReturnBook(bookID, memberID); err != nil {
-				fmt.Println("❌", err)
+				fmt.Println("❌", describe(err))
 			} else {
 				fmt.Println("✅ Book returned successfully.")
 			}
@@ -78,15 +148,56 @@ func StartConsoleApp() {
 			}
 
 		case 6:
-			var memberID int
-			fmt.Print("Enter member ID: ")
-			fmt.Scanln(&memberID)
+			memberID, ok := c.readInt("Enter member ID: ")
+			if !ok {
+				continue
+			}
 			fmt.Printf("\n👤 Borrowed Books for Member %d:\n", memberID)
 			for _, book := range library.ListBorrowedBooks(memberID) {
 				fmt.Printf("[%d] %s by %s\n", book.ID, book.Title, book.Author)
 			}
 
 		case 7:
+			name, ok := c.readLine("Enter member name: ")
+			if !ok {
+				continue
+			}
+			if member, err := library.AddMember(models.Member{Name: name}); err != nil {
+				fmt.Println("❌", describe(err))
+			} else {
+				fmt.Printf("✅ Member added with ID %d.\n", member.ID)
+			}
+
+		case 8:
+			id, ok1 := c.readInt("Enter member ID: ")
+			name, ok2 := c.readLine("Enter new name: ")
+			if !ok1 || !ok2 {
+				continue
+			}
+			if err := library.UpdateMember(models.Member{ID: id, Name: name}); err != nil {
+				fmt.Println("❌", describe(err))
+			} else {
+				fmt.Println("✅ Member updated successfully.")
+			}
+
+		case 9:
+			id, ok := c.readInt("Enter member ID to remove: ")
+			if !ok {
+				continue
+			}
+			if err := library.RemoveMember(id); err != nil {
+				fmt.Println("❌", describe(err))
+			} else {
+				fmt.Println("🗑️ Member removed successfully.")
+			}
+
+		case 10:
+			fmt.Println("\n👥 Members:")
+			for _, member := range library.ListMembers() {
+				fmt.Printf("[%d] %s (%d borrowed)\n", member.ID, member.Name, len(member.BorrowedBooks))
+			}
+
+		case 11:
 			fmt.Println("👋 Exiting... Goodbye!")
 			return
 
@@ -1,7 +1,59 @@
 package main
 
-import "library-management/controllers"
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"library-management/controllers"
+	"library-management/services"
+	"library-management/storage"
+)
 
 func main() {
-	controllers.StartConsoleApp()
+	storeKind := flag.String("store", "json", "where to keep the library: json, sqlite or memory")
+	path := flag.String("data", "", "data file (default library.json or library.db)")
+	flag.Parse()
+
+	library, closeStore, err := openLibrary(*storeKind, *path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "library:", err)
+		os.Exit(1)
+	}
+	defer closeStore()
+
+	controllers.StartConsoleApp(library)
+}
+
+// openLibrary loads the library from the chosen store. The returned function
+// closes the store.
+func openLibrary(kind, path string) (*services.Library, func() error, error) {
+	var store storage.Store
+	switch kind {
+	case "memory":
+		return services.NewLibrary(), func() error { return nil }, nil
+	case "json":
+		if path == "" {
+			path = "library.json"
+		}
+		store = storage.NewJSONStore(path)
+	case "sqlite":
+		if path == "" {
+			path = "library.db"
+		}
+		s, err := storage.OpenSQLite(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		store = s
+	default:
+		return nil, nil, fmt.Errorf("unknown store %q (want json, sqlite or memory)", kind)
+	}
+
+	library, err := services.NewLibraryWithStore(store)
+	if err != nil {
+		store.Close()
+		return nil, nil, err
+	}
+	return library, store.Close, nil
 }
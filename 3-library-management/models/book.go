@@ -1,8 +1,8 @@
 package models
 
 type Book struct {
-	ID     int
-	Title  string
-	Author string
-	Status string // "Available" or "Borrowed"
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	Status string `json:"status"` // "Available" or "Borrowed"
 }
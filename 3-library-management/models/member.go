@@ -1,7 +1,7 @@
 package models
 
-type Member struct{
-	ID int
-	Name string
-	BorrowedBooks []Book
-}
\ No newline at end of file
+type Member struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	BorrowedBooks []Book `json:"borrowed_books"`
+}
@@ -1,70 +1,154 @@
 package services
 
 import (
+	"cmp"
 	"errors"
-	"library_management/models"
+	"fmt"
+	"library-management/models"
+	"library-management/storage"
+	"slices"
+	"strings"
+)
+
+// Errors returned by LibraryManager. Check for them with errors.Is, since
+// some are wrapped with more detail.
+var (
+	ErrBookNotFound        = errors.New("book not found")
+	ErrBookExists          = errors.New("a book with this ID already exists")
+	ErrAlreadyBorrowed     = errors.New("book already borrowed")
+	ErrBookOnLoan          = errors.New("book is on loan")
+	ErrMemberNotFound      = errors.New("member not found")
+	ErrMemberExists        = errors.New("a member with this ID already exists")
+	ErrMemberHasBooks      = errors.New("member still has borrowed books")
+	ErrNotBorrowedByMember = errors.New("book not borrowed by this member")
+	ErrNameRequired        = errors.New("member name is required")
 )
 
 type LibraryManager interface {
-	AddBook(book models.Book)
-	RemoveBook(bookID int)
+	AddBook(book models.Book) error
+	RemoveBook(bookID int) error
 	BorrowBook(bookID int, memberID int) error
 	ReturnBook(bookID int, memberID int) error
 	ListAvailableBooks() []models.Book
 	ListBorrowedBooks(memberID int) []models.Book
+	AddMember(member models.Member) (models.Member, error)
+	UpdateMember(member models.Member) error
+	RemoveMember(memberID int) error
+	ListMembers() []models.Member
 }
 
+// Library is the LibraryManager used by the console app. Its books and
+// members are only changed through its methods, so that every change is saved
+// to the store and undone if saving fails.
 type Library struct {
-	Books   map[int]models.Book
-	Members map[int]models.Member
+	books   map[int]models.Book
+	members map[int]models.Member
+	store   storage.Store // nil keeps the library in memory only
 }
 
+// NewLibrary returns an empty library that is not persisted.
 func NewLibrary() *Library {
 	return &Library{
-		Books:   make(map[int]models.Book),
-		Members: make(map[int]models.Member),
+		books:   make(map[int]models.Book),
+		members: make(map[int]models.Member),
+	}
+}
+
+// NewLibraryWithStore returns a library loaded from store. Every successful
+// mutation is saved back to it.
+func NewLibraryWithStore(store storage.Store) (*Library, error) {
+	data, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load library: %w", err)
 	}
+	return &Library{books: data.Books, members: data.Members, store: store}, nil
+}
+
+// save persists the library after a mutation. If that fails, rollback is
+// called to undo the mutation in memory, so memory and store stay in step.
+func (l *Library) save(rollback func()) error {
+	if l.store == nil {
+		return nil
+	}
+	if err := l.store.Save(storage.Data{Books: l.books, Members: l.members}); err != nil {
+		rollback()
+		return fmt.Errorf("save library: %w", err)
+	}
+	return nil
+}
+
+// Book returns the book with the given ID.
+func (l *Library) Book(bookID int) (models.Book, bool) {
+	book, ok := l.books[bookID]
+	return book, ok
+}
+
+// Member returns a copy of the member with the given ID.
+func (l *Library) Member(memberID int) (models.Member, bool) {
+	member, ok := l.members[memberID]
+	member.BorrowedBooks = slices.Clone(member.BorrowedBooks)
+	return member, ok
 }
 
-func (l *Library) AddBook(book models.Book) {
+// AddBook adds a new, available book. It fails with ErrBookExists if the ID
+// is taken.
+func (l *Library) AddBook(book models.Book) error {
+	if _, exists := l.books[book.ID]; exists {
+		return fmt.Errorf("%w: %d", ErrBookExists, book.ID)
+	}
 	book.Status = "Available"
-	l.Books[book.ID] = book
+	l.books[book.ID] = book
+	return l.save(func() { delete(l.books, book.ID) })
 }
 
-func (l *Library) RemoveBook(bookID int) {
-	delete(l.Books, bookID)
+// RemoveBook deletes a book. It fails with ErrBookOnLoan while the book is
+// borrowed, so no member is left holding a book the library no longer has.
+func (l *Library) RemoveBook(bookID int) error {
+	book, ok := l.books[bookID]
+	if !ok {
+		return ErrBookNotFound
+	}
+	if book.Status == "Borrowed" {
+		return ErrBookOnLoan
+	}
+	delete(l.books, bookID)
+	return l.save(func() { l.books[bookID] = book })
 }
 
 func (l *Library) BorrowBook(bookID int, memberID int) error {
-	book, ok := l.Books[bookID]
+	book, ok := l.books[bookID]
 	if !ok {
-		return errors.New("book not found")
+		return ErrBookNotFound
 	}
 	if book.Status == "Borrowed" {
-		return errors.New("book already borrowed")
+		return ErrAlreadyBorrowed
 	}
 
-	member, ok := l.Members[memberID]
+	member, ok := l.members[memberID]
 	if !ok {
-		return errors.New("member not found")
+		return ErrMemberNotFound
 	}
 
+	oldBook, oldMember := book, member
 	book.Status = "Borrowed"
 	member.BorrowedBooks = append(member.BorrowedBooks, book)
-	l.Members[memberID] = member
-	l.Books[bookID] = book
-	return nil
+	l.members[memberID] = member
+	l.books[bookID] = book
+	return l.save(func() {
+		l.books[bookID] = oldBook
+		l.members[memberID] = oldMember
+	})
 }
 
 func (l *Library) ReturnBook(bookID int, memberID int) error {
-	member, ok := l.Members[memberID]
+	member, ok := l.members[memberID]
 	if !ok {
-		return errors.New("member not found")
+		return ErrMemberNotFound
 	}
 
-	book, ok := l.Books[bookID]
+	book, ok := l.books[bookID]
 	if !ok {
-		return errors.New("book not found")
+		return ErrBookNotFound
 	}
 
 	found := false
@@ -78,19 +162,23 @@ func (l *Library) ReturnBook(bookID int, memberID int) error {
 	}
 
 	if !found {
-		return errors.New("book not borrowed by this member")
+		return ErrNotBorrowedByMember
 	}
 
+	oldBook, oldMember := book, member
 	book.Status = "Available"
 	member.BorrowedBooks = newBorrowedBooks
-	l.Books[bookID] = book
-	l.Members[memberID] = member
-	return nil
+	l.books[bookID] = book
+	l.members[memberID] = member
+	return l.save(func() {
+		l.books[bookID] = oldBook
+		l.members[memberID] = oldMember
+	})
 }
 
 func (l *Library) ListAvailableBooks() []models.Book {
 	var available []models.Book
-	for _, book := range l.Books {
+	for _, book := range l.books {
 		if book.Status == "Available" {
 			available = append(available, book)
 		}
@@ -99,9 +187,78 @@ func (l *Library) ListAvailableBooks() []models.Book {
 }
 
 func (l *Library) ListBorrowedBooks(memberID int) []models.Book {
-	member, ok := l.Members[memberID]
+	member, ok := l.members[memberID]
 	if !ok {
 		return []models.Book{}
 	}
-	return member.BorrowedBooks
+	return slices.Clone(member.BorrowedBooks)
+}
+
+// AddMember registers a new member and returns it. A zero ID is replaced by
+// the next free one. New members start with no borrowed books.
+func (l *Library) AddMember(member models.Member) (models.Member, error) {
+	member.Name = strings.TrimSpace(member.Name)
+	if member.Name == "" {
+		return models.Member{}, ErrNameRequired
+	}
+	if member.ID == 0 {
+		for id := range l.members {
+			member.ID = max(member.ID, id)
+		}
+		member.ID++
+	}
+	if _, exists := l.members[member.ID]; exists {
+		return models.Member{}, fmt.Errorf("%w: %d", ErrMemberExists, member.ID)
+	}
+
+	member.BorrowedBooks = nil
+	l.members[member.ID] = member
+	if err := l.save(func() { delete(l.members, member.ID) }); err != nil {
+		return models.Member{}, err
+	}
+	return member, nil
+}
+
+// UpdateMember changes the name of an existing member. Borrowed books are
+// managed through BorrowBook and ReturnBook and are left untouched.
+func (l *Library) UpdateMember(member models.Member) error {
+	old, ok := l.members[member.ID]
+	if !ok {
+		return ErrMemberNotFound
+	}
+	name := strings.TrimSpace(member.Name)
+	if name == "" {
+		return ErrNameRequired
+	}
+
+	updated := old
+	updated.Name = name
+	l.members[member.ID] = updated
+	return l.save(func() { l.members[member.ID] = old })
+}
+
+// RemoveMember deletes a member. Members who still have borrowed books
+// cannot be removed until they return them.
+func (l *Library) RemoveMember(memberID int) error {
+	member, ok := l.members[memberID]
+	if !ok {
+		return ErrMemberNotFound
+	}
+	if len(member.BorrowedBooks) > 0 {
+		return fmt.Errorf("%w (%d)", ErrMemberHasBooks, len(member.BorrowedBooks))
+	}
+
+	delete(l.members, memberID)
+	return l.save(func() { l.members[memberID] = member })
+}
+
+// ListMembers returns all members ordered by ID.
+func (l *Library) ListMembers() []models.Member {
+	members := make([]models.Member, 0, len(l.members))
+	for _, m := range l.members {
+		m.BorrowedBooks = slices.Clone(m.BorrowedBooks)
+		members = append(members, m)
+	}
+	slices.SortFunc(members, func(a, b models.Member) int { return cmp.Compare(a.ID, b.ID) })
+	return members
 }
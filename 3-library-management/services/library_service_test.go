@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"library-management/models"
+	"library-management/storage"
+)
+
+// failingStore is a storage.Store whose saves can be made to fail.
+type failingStore struct {
+	fail  bool
+	saves int
+}
+
+func (s *failingStore) Load() (storage.Data, error) {
+	return storage.Data{Books: map[int]models.Book{}, Members: map[int]models.Member{}}, nil
+}
+
+func (s *failingStore) Save(storage.Data) error {
+	if s.fail {
+		return errors.New("disk full")
+	}
+	s.saves++
+	return nil
+}
+
+func (s *failingStore) Close() error { return nil }
+
+func TestLibraryPersistsMutations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "library.json")
+
+	l, err := NewLibraryWithStore(storage.NewJSONStore(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.AddMember(models.Member{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddBook(models.Book{ID: 1, Title: "Emma", Author: "Austen"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddBook(models.Book{ID: 2, Title: "Dune", Author: "Herbert"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.BorrowBook(2, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restart reloads everything from the file.
+	l, err = NewLibraryWithStore(storage.NewJSONStore(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := l.ListAvailableBooks(); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("expected book 1 available, got %v", got)
+	}
+	if got := l.ListBorrowedBooks(1); len(got) != 1 || got[0].ID != 2 || got[0].Status != "Borrowed" {
+		t.Errorf("expected member 1 to have book 2, got %v", got)
+	}
+	if m, ok := l.Member(1); !ok || m.Name != "Alice" {
+		t.Errorf("expected member 1 to be Alice after a restart, got %+v", m)
+	}
+
+	// Results are copies: changing them does not bypass the store.
+	m, _ := l.Member(1)
+	m.BorrowedBooks[0].Title = "Changed"
+	l.ListBorrowedBooks(1)[0].Title = "Changed"
+	if got := l.ListBorrowedBooks(1); got[0].Title != "Dune" {
+		t.Errorf("expected the library to be unaffected by changes to returned values, got %v", got)
+	}
+
+	if err := l.ReturnBook(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RemoveBook(1); err != nil {
+		t.Fatal(err)
+	}
+	l, err = NewLibraryWithStore(storage.NewJSONStore(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book, ok := l.Book(2); len(l.books) != 1 || !ok || book.Status != "Available" || len(l.ListBorrowedBooks(1)) != 0 {
+		t.Errorf("expected only book 2, available and not borrowed, got %v and %v", l.books, l.members)
+	}
+}
+
+func TestLibraryRollsBackFailedSave(t *testing.T) {
+	store := &failingStore{}
+	l, err := NewLibraryWithStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.AddMember(models.Member{Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.AddBook(models.Book{ID: 1, Title: "Emma"}); err != nil {
+		t.Fatal(err)
+	}
+
+	store.fail = true
+	if err := l.AddBook(models.Book{ID: 2, Title: "Dune"}); err == nil {
+		t.Error("expected AddBook to report the failed save")
+	}
+	if _, ok := l.Book(2); ok {
+		t.Error("expected the failed AddBook to be undone")
+	}
+	if err := l.BorrowBook(1, 1); err == nil {
+		t.Error("expected BorrowBook to report the failed save")
+	}
+	if book, _ := l.Book(1); book.Status != "Available" || len(l.ListBorrowedBooks(1)) != 0 {
+		t.Errorf("expected the failed BorrowBook to be undone, got %v and %v", book, l.ListBorrowedBooks(1))
+	}
+	if err := l.RemoveBook(1); err == nil {
+		t.Error("expected RemoveBook to report the failed save")
+	}
+	if _, ok := l.Book(1); !ok {
+		t.Error("expected the failed RemoveBook to be undone")
+	}
+	if _, err := l.AddMember(models.Member{Name: "Bob"}); err == nil {
+		t.Error("expected AddMember to report the failed save")
+	}
+	if _, ok := l.Member(2); ok {
+		t.Error("expected the failed AddMember to be undone")
+	}
+	if store.saves != 2 {
+		t.Errorf("expected 2 successful saves, got %d", store.saves)
+	}
+}
+
+func TestMemberManagement(t *testing.T) {
+	l := NewLibrary()
+
+	alice, err := l.AddMember(models.Member{Name: "  Alice  "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice.ID != 1 || alice.Name != "Alice" {
+		t.Errorf("expected member 1 named Alice, got %+v", alice)
+	}
+	if _, err := l.AddMember(models.Member{ID: 10, Name: "Bob"}); err != nil {
+		t.Fatal(err)
+	}
+	carol, err := l.AddMember(models.Member{Name: "Carol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if carol.ID != 11 {
+		t.Errorf("expected the next free ID 11, got %d", carol.ID)
+	}
+
+	if _, err := l.AddMember(models.Member{ID: 10, Name: "Dup"}); !errors.Is(err, ErrMemberExists) {
+		t.Errorf("expected ErrMemberExists for a duplicate ID, got %v", err)
+	}
+	if _, err := l.AddMember(models.Member{Name: " "}); !errors.Is(err, ErrNameRequired) {
+		t.Errorf("expected ErrNameRequired for an empty name, got %v", err)
+	}
+
+	if err := l.UpdateMember(models.Member{ID: 10, Name: "Robert"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.UpdateMember(models.Member{ID: 99, Name: "Nobody"}); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound updating an unknown member, got %v", err)
+	}
+	if err := l.UpdateMember(models.Member{ID: 10}); !errors.Is(err, ErrNameRequired) {
+		t.Errorf("expected ErrNameRequired for an empty name, got %v", err)
+	}
+
+	members := l.ListMembers()
+	var names []string
+	for _, m := range members {
+		names = append(names, m.Name)
+	}
+	if expected := []string{"Alice", "Robert", "Carol"}; !slices.Equal(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	if err := l.AddBook(models.Book{ID: 1, Title: "Emma"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.BorrowBook(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.UpdateMember(models.Member{ID: 1, Name: "Alice B."}); err != nil {
+		t.Fatal(err)
+	}
+	if got := l.ListBorrowedBooks(1); len(got) != 1 {
+		t.Errorf("expected UpdateMember to keep borrowed books, got %v", got)
+	}
+	if err := l.RemoveMember(1); !errors.Is(err, ErrMemberHasBooks) {
+		t.Errorf("expected ErrMemberHasBooks for a member with borrowed books, got %v", err)
+	}
+	if err := l.ReturnBook(1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RemoveMember(1); err != nil {
+		t.Errorf("expected RemoveMember to succeed after the return, got %v", err)
+	}
+	if err := l.RemoveMember(1); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("expected ErrMemberNotFound removing an unknown member, got %v", err)
+	}
+	if len(l.ListMembers()) != 2 {
+		t.Errorf("expected 2 members left, got %v", l.ListMembers())
+	}
+}
+
+func TestLibraryErrors(t *testing.T) {
+	newLibrary := func(t *testing.T) *Library {
+		t.Helper()
+		l := NewLibrary()
+		for _, name := range []string{"Alice", "Bob"} {
+			if _, err := l.AddMember(models.Member{Name: name}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for id, title := range map[int]string{1: "Emma", 2: "Dune"} {
+			if err := l.AddBook(models.Book{ID: id, Title: title}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := l.BorrowBook(2, 1); err != nil {
+			t.Fatal(err)
+		}
+		return l
+	}
+
+	tests := []struct {
+		name     string
+		op       func(l *Library) error
+		expected error
+	}{
+		{"add duplicate book", func(l *Library) error { return l.AddBook(models.Book{ID: 1, Title: "Other"}) }, ErrBookExists},
+		{"remove unknown book", func(l *Library) error { return l.RemoveBook(9) }, ErrBookNotFound},
+		{"remove borrowed book", func(l *Library) error { return l.RemoveBook(2) }, ErrBookOnLoan},
+		{"borrow unknown book", func(l *Library) error { return l.BorrowBook(9, 1) }, ErrBookNotFound},
+		{"borrow borrowed book", func(l *Library) error { return l.BorrowBook(2, 2) }, ErrAlreadyBorrowed},
+		{"borrow for unknown member", func(l *Library) error { return l.BorrowBook(1, 9) }, ErrMemberNotFound},
+		{"return for unknown member", func(l *Library) error { return l.ReturnBook(2, 9) }, ErrMemberNotFound},
+		{"return unknown book", func(l *Library) error { return l.ReturnBook(9, 1) }, ErrBookNotFound},
+		{"return book borrowed by someone else", func(l *Library) error { return l.ReturnBook(2, 2) }, ErrNotBorrowedByMember},
+		{"return available book", func(l *Library) error { return l.ReturnBook(1, 1) }, ErrNotBorrowedByMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLibrary(t)
+			if err := tt.op(l); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+			// A failed operation leaves the library unchanged.
+			if len(l.books) != 2 || l.books[1].Title != "Emma" || l.books[2].Status != "Borrowed" || len(l.members[1].BorrowedBooks) != 1 {
+				t.Errorf("expected the library to be unchanged, got %v and %v", l.books, l.members)
+			}
+		})
+	}
+
+	l := newLibrary(t)
+	if err := l.ReturnBook(2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.RemoveBook(2); err != nil {
+		t.Errorf("expected a returned book to be removable, got %v", err)
+	}
+}
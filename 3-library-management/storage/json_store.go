@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"library-management/models"
+)
+
+// JSONStore keeps the library in a single JSON file.
+// A member's borrowed books are stored as IDs referring to the books list.
+type JSONStore struct {
+	path string
+}
+
+// jsonFile is the layout of the file written by JSONStore.
+type jsonFile struct {
+	Books   []models.Book `json:"books"`
+	Members []jsonMember  `json:"members"`
+}
+
+type jsonMember struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	BorrowedBookIDs []int  `json:"borrowed_book_ids,omitempty"`
+}
+
+// NewJSONStore returns a store backed by the file at path. The file is
+// created on the first Save.
+func NewJSONStore(path string) *JSONStore {
+	return &JSONStore{path: path}
+}
+
+// Load reads the file, returning empty data if it does not exist yet.
+func (s *JSONStore) Load() (Data, error) {
+	d := emptyData()
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	if err != nil {
+		return Data{}, err
+	}
+
+	var f jsonFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return Data{}, err
+	}
+	for _, b := range f.Books {
+		d.Books[b.ID] = b
+	}
+	loans := make(map[int][]int)
+	for _, m := range f.Members {
+		d.Members[m.ID] = models.Member{ID: m.ID, Name: m.Name}
+		loans[m.ID] = m.BorrowedBookIDs
+	}
+	attachLoans(d, loans)
+	return d, nil
+}
+
+// Save writes d to a temporary file in the same directory and renames it
+// over the old file, so a crash never leaves a half-written library behind.
+func (s *JSONStore) Save(d Data) error {
+	f := jsonFile{Books: sortedBooks(d.Books), Members: []jsonMember{}}
+	for _, m := range sortedMembers(d.Members) {
+		f.Members = append(f.Members, jsonMember{ID: m.ID, Name: m.Name, BorrowedBookIDs: bookIDs(m.BorrowedBooks)})
+	}
+	raw, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(append(raw, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Close implements Store. It has nothing to release.
+func (s *JSONStore) Close() error {
+	return nil
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"database/sql"
+
+	"library-management/models"
+
+	_ "modernc.org/sqlite" // registers the pure-Go "sqlite" driver
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS books (
+	id     INTEGER PRIMARY KEY,
+	title  TEXT NOT NULL,
+	author TEXT NOT NULL,
+	status TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS members (
+	id   INTEGER PRIMARY KEY,
+	name TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS loans (
+	member_id INTEGER NOT NULL,
+	book_id   INTEGER NOT NULL,
+	position  INTEGER NOT NULL,
+	PRIMARY KEY (member_id, position)
+);`
+
+// SQLiteStore keeps the library in an embedded SQLite database file.
+// A member's borrowed books are stored as loans referring to the books table.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens, or creates, the database at path.
+func OpenSQLite(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+// Load reads all books, members and loans. A loan whose book no longer
+// exists is skipped.
+func (s *SQLiteStore) Load() (Data, error) {
+	d := emptyData()
+
+	rows, err := s.db.Query(`SELECT id, title, author, status FROM books`)
+	if err != nil {
+		return Data{}, err
+	}
+	for rows.Next() {
+		var b models.Book
+		if err := rows.Scan(&b.ID, &b.Title, &b.Author, &b.Status); err != nil {
+			rows.Close()
+			return Data{}, err
+		}
+		d.Books[b.ID] = b
+	}
+	if err := closeRows(rows); err != nil {
+		return Data{}, err
+	}
+
+	rows, err = s.db.Query(`SELECT id, name FROM members`)
+	if err != nil {
+		return Data{}, err
+	}
+	for rows.Next() {
+		var m models.Member
+		if err := rows.Scan(&m.ID, &m.Name); err != nil {
+			rows.Close()
+			return Data{}, err
+		}
+		d.Members[m.ID] = m
+	}
+	if err := closeRows(rows); err != nil {
+		return Data{}, err
+	}
+
+	rows, err = s.db.Query(`SELECT member_id, book_id FROM loans ORDER BY member_id, position`)
+	if err != nil {
+		return Data{}, err
+	}
+	loans := make(map[int][]int)
+	for rows.Next() {
+		var memberID, bookID int
+		if err := rows.Scan(&memberID, &bookID); err != nil {
+			rows.Close()
+			return Data{}, err
+		}
+		loans[memberID] = append(loans[memberID], bookID)
+	}
+	if err := closeRows(rows); err != nil {
+		return Data{}, err
+	}
+	attachLoans(d, loans)
+	return d, nil
+}
+
+// Save replaces the contents of all tables with d in one transaction.
+func (s *SQLiteStore) Save(d Data) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // no-op after Commit
+
+	for _, table := range []string{"loans", "books", "members"} {
+		if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+			return err
+		}
+	}
+	for _, b := range sortedBooks(d.Books) {
+		if _, err := tx.Exec(`INSERT INTO books (id, title, author, status) VALUES (?, ?, ?, ?)`,
+			b.ID, b.Title, b.Author, b.Status); err != nil {
+			return err
+		}
+	}
+	for _, m := range sortedMembers(d.Members) {
+		if _, err := tx.Exec(`INSERT INTO members (id, name) VALUES (?, ?)`, m.ID, m.Name); err != nil {
+			return err
+		}
+		for i, id := range bookIDs(m.BorrowedBooks) {
+			if _, err := tx.Exec(`INSERT INTO loans (member_id, book_id, position) VALUES (?, ?, ?)`,
+				m.ID, id, i); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+func closeRows(rows *sql.Rows) error {
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	return rows.Close()
+}
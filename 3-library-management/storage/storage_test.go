@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"library-management/models"
+)
+
+func sampleData() Data {
+	borrowed := models.Book{ID: 2, Title: "Dune", Author: "Herbert", Status: "Borrowed"}
+	return Data{
+		Books: map[int]models.Book{
+			1: {ID: 1, Title: "Emma", Author: "Austen", Status: "Available"},
+			2: borrowed,
+		},
+		Members: map[int]models.Member{
+			1: {ID: 1, Name: "Alice", BorrowedBooks: []models.Book{borrowed}},
+			2: {ID: 2, Name: "Bob"},
+		},
+	}
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T, dir string) Store{
+		"json": func(t *testing.T, dir string) Store {
+			return NewJSONStore(filepath.Join(dir, "library.json"))
+		},
+		"sqlite": func(t *testing.T, dir string) Store {
+			s, err := OpenSQLite(filepath.Join(dir, "library.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return s
+		},
+	}
+
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			s := open(t, dir)
+			d, err := s.Load()
+			if err != nil {
+				t.Fatalf("load before first save: %v", err)
+			}
+			if len(d.Books) != 0 || len(d.Members) != 0 || d.Books == nil || d.Members == nil {
+				t.Errorf("expected empty non-nil maps, got %+v", d)
+			}
+
+			expected := sampleData()
+			if err := s.Save(expected); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Reopen to make sure the data really was persisted.
+			s = open(t, dir)
+			defer s.Close()
+			got, err := s.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Bob has no loans, so his BorrowedBooks stays nil in both stores.
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected %+v, got %+v", expected, got)
+			}
+
+			// Loans are rebuilt from Books, not from the member's own copies.
+			stale := sampleData()
+			alice := stale.Members[1]
+			alice.BorrowedBooks = []models.Book{{ID: 2, Title: "Old title"}, {ID: 9}}
+			stale.Members[1] = alice
+			if err := s.Save(stale); err != nil {
+				t.Fatal(err)
+			}
+			if got, err = s.Load(); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("expected loans rebuilt from books %+v, got %+v", expected, got)
+			}
+
+			// Saving again replaces the previous contents.
+			delete(expected.Books, 1)
+			delete(expected.Members, 2)
+			if err := s.Save(expected); err != nil {
+				t.Fatal(err)
+			}
+			got, err = s.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("after second save: expected %+v, got %+v", expected, got)
+			}
+		})
+	}
+}
+
+func TestJSONStoreAtomicSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "library.json")
+	s := NewJSONStore(path)
+	if err := s.Save(sampleData()); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "library.json" {
+		t.Errorf("expected only library.json to remain, got %v", entries)
+	}
+
+	// A save into a directory that does not exist fails without touching anything.
+	bad := NewJSONStore(filepath.Join(dir, "missing", "library.json"))
+	if err := bad.Save(sampleData()); err == nil {
+		t.Error("expected an error saving into a missing directory")
+	}
+}
+
+func TestJSONStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "library.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJSONStore(path).Load(); err == nil {
+		t.Error("expected an error loading a corrupt file")
+	}
+}
@@ -0,0 +1,81 @@
+// Package storage persists the library's books and members.
+package storage
+
+import (
+	"cmp"
+	"slices"
+
+	"library-management/models"
+)
+
+// Data is everything the library persists. Stores save only the IDs of a
+// member's borrowed books and rebuild BorrowedBooks from Books on Load, so
+// Books is the single source of truth for every store.
+type Data struct {
+	Books   map[int]models.Book
+	Members map[int]models.Member
+}
+
+// Store loads and saves the library's data.
+type Store interface {
+	// Load returns the saved data, or empty maps if nothing was saved yet.
+	Load() (Data, error)
+	// Save replaces the saved data with d.
+	Save(d Data) error
+	// Close releases the store's resources.
+	Close() error
+}
+
+func emptyData() Data {
+	return Data{
+		Books:   make(map[int]models.Book),
+		Members: make(map[int]models.Member),
+	}
+}
+
+// bookIDs returns the IDs of books, in order.
+func bookIDs(books []models.Book) []int {
+	var ids []int
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+// attachLoans sets the BorrowedBooks of each member in loans, which maps a
+// member ID to the IDs of its borrowed books in order. Loans of unknown
+// members or books are skipped.
+func attachLoans(d Data, loans map[int][]int) {
+	for memberID, ids := range loans {
+		m, ok := d.Members[memberID]
+		if !ok {
+			continue
+		}
+		for _, id := range ids {
+			if b, ok := d.Books[id]; ok {
+				m.BorrowedBooks = append(m.BorrowedBooks, b)
+			}
+		}
+		d.Members[memberID] = m
+	}
+}
+
+// sortedBooks returns the books ordered by ID so saved files are stable.
+func sortedBooks(books map[int]models.Book) []models.Book {
+	out := make([]models.Book, 0, len(books))
+	for _, b := range books {
+		out = append(out, b)
+	}
+	slices.SortFunc(out, func(a, b models.Book) int { return cmp.Compare(a.ID, b.ID) })
+	return out
+}
+
+// sortedMembers returns the members ordered by ID so saved files are stable.
+func sortedMembers(members map[int]models.Member) []models.Member {
+	out := make([]models.Member, 0, len(members))
+	for _, m := range members {
+		out = append(out, m)
+	}
+	slices.SortFunc(out, func(a, b models.Member) int { return cmp.Compare(a.ID, b.ID) })
+	return out
+}